@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"mcolomerc/vvp2cli/pkg/api"
+
+	"github.com/spf13/cobra"
+)
+
+var apiTokenCmd = &cobra.Command{
+	Use:     "api-token",
+	Aliases: []string{"api-tokens", "apitoken", "apitokens"},
+	Short:   "Manage VVP API tokens",
+	Long:    `Create, list, view, and delete namespace-scoped Ververica Platform API tokens, e.g. for CI pipelines.`,
+}
+
+var apiTokenListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List API tokens in a namespace",
+	RunE:    runAPITokenList,
+}
+
+var apiTokenGetCmd = &cobra.Command{
+	Use:   "get [name]",
+	Short: "Get an API token by name",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAPITokenGet,
+}
+
+var apiTokenCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "Create an API token",
+	Long: `Create an API token with the given role (viewer, editor or owner).
+
+The token secret is shown only once and cannot be retrieved later. With
+--secret-file it is written to that file (mode 0600) instead of being printed.`,
+	Example: `  vvp2 api-token create ci --role editor -n analytics
+  vvp2 api-token create ci --role editor --secret-file ~/.vvp2/ci-token`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAPITokenCreate,
+}
+
+var apiTokenDeleteCmd = &cobra.Command{
+	Use:     "delete [name]",
+	Aliases: []string{"rm"},
+	Short:   "Delete an API token",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runAPITokenDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(apiTokenCmd)
+
+	apiTokenCmd.AddCommand(apiTokenListCmd)
+	apiTokenCmd.AddCommand(apiTokenGetCmd)
+	apiTokenCmd.AddCommand(apiTokenCreateCmd)
+	apiTokenCmd.AddCommand(apiTokenDeleteCmd)
+
+	// Add flags
+	apiTokenListCmd.Flags().StringP("namespace", "n", "", "Namespace")
+	apiTokenGetCmd.Flags().StringP("namespace", "n", "", "Namespace")
+
+	apiTokenCreateCmd.Flags().StringP("namespace", "n", "", "Namespace")
+	apiTokenCreateCmd.Flags().String("role", "viewer", "Role of the token ("+strings.Join(api.APITokenRoles, ", ")+")")
+	apiTokenCreateCmd.Flags().String("secret-file", "", "Write the token secret to this file (mode 0600) instead of printing it")
+
+	apiTokenDeleteCmd.Flags().StringP("namespace", "n", "", "Namespace")
+}
+
+func runAPITokenList(cmd *cobra.Command, args []string) error {
+	namespace, err := resolveNamespace(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	tokens, err := client.ListAPITokensContext(cmd.Context(), namespace)
+	if err != nil {
+		return err
+	}
+
+	return printAPITokens(tokens.APITokens)
+}
+
+func runAPITokenGet(cmd *cobra.Command, args []string) error {
+	namespace, err := resolveNamespace(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	token, err := client.GetAPITokenContext(cmd.Context(), namespace, args[0])
+	if err != nil {
+		return err
+	}
+
+	return printAPIToken(token)
+}
+
+func runAPITokenCreate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	namespace, err := resolveNamespace(cmd)
+	if err != nil {
+		return err
+	}
+
+	role, _ := cmd.Flags().GetString("role")
+	if !validAPITokenRole(role) {
+		return fmt.Errorf("invalid role %q (valid roles: %s)", role, strings.Join(api.APITokenRoles, ", "))
+	}
+
+	// Check the secret file can be written before creating a token whose
+	// secret would otherwise be lost. The secret goes to a temporary file
+	// that replaces the target only once the token exists, so a failed
+	// create never clobbers an existing secret file.
+	secretFile, _ := cmd.Flags().GetString("secret-file")
+	var out *os.File
+	if secretFile != "" {
+		if info, err := os.Stat(secretFile); err == nil && info.IsDir() {
+			return fmt.Errorf("secret file %s is a directory", secretFile)
+		}
+		// CreateTemp creates the file with mode 0600
+		out, err = os.CreateTemp(filepath.Dir(secretFile), "."+filepath.Base(secretFile)+".*")
+		if err != nil {
+			return fmt.Errorf("failed to create secret file: %w", err)
+		}
+		defer os.Remove(out.Name())
+		defer out.Close()
+	}
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	token, err := client.CreateAPITokenContext(cmd.Context(), namespace, &api.APIToken{Name: name, Role: role})
+	if err != nil {
+		return err
+	}
+	secret := token.Secret
+
+	if out != nil {
+		if err := writeSecretFile(out, secretFile, secret); err != nil {
+			// The token exists now, so do not lose its only copy of the secret
+			fmt.Fprintf(os.Stderr, "Secret: %s\n", secret)
+			return fmt.Errorf("API token '%s' created, but failed to write secret file: %w", token.ShortName(), err)
+		}
+		// Keep the secret out of the printed token
+		token.Secret = ""
+	}
+
+	if !isTableOutput() {
+		return printAPIToken(token)
+	}
+
+	fmt.Printf("API token '%s' created with role %s\n", token.ShortName(), token.Role)
+	if out != nil {
+		fmt.Printf("Secret written to %s\n", secretFile)
+		return nil
+	}
+	fmt.Printf("\nSecret: %s\n\n", secret)
+	fmt.Println("Store the secret now; it cannot be retrieved again.")
+	return nil
+}
+
+// writeSecretFile writes secret to the temporary file tmp and moves it over
+// path
+func writeSecretFile(tmp *os.File, path, secret string) error {
+	if _, err := fmt.Fprintln(tmp, secret); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func runAPITokenDelete(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	namespace, err := resolveNamespace(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	if err := client.DeleteAPITokenContext(cmd.Context(), namespace, name); err != nil {
+		return err
+	}
+
+	fmt.Printf("API token '%s' deleted successfully\n", name)
+	return nil
+}
+
+func validAPITokenRole(role string) bool {
+	for _, r := range api.APITokenRoles {
+		if role == r {
+			return true
+		}
+	}
+	return false
+}
+
+// Helper functions for printing API tokens
+func printAPITokens(tokens []api.APIToken) error {
+	return printOutput("APIToken", tokens, func() error {
+		// Table format
+		if len(tokens) == 0 {
+			fmt.Println("No API tokens found")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "NAME\tROLE\tCREATED")
+		for _, token := range tokens {
+			created := "-"
+			if !token.CreateTime.IsZero() {
+				created = token.CreateTime.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", token.ShortName(), token.Role, created)
+		}
+		return w.Flush()
+	})
+}
+
+func printAPIToken(token *api.APIToken) error {
+	return printOutput("APIToken", token, func() error {
+		fmt.Printf("Name: %s\n", token.ShortName())
+		fmt.Printf("Resource Name: %s\n", token.Name)
+		fmt.Printf("Role: %s\n", token.Role)
+		if !token.CreateTime.IsZero() {
+			fmt.Printf("Created At: %s\n", token.CreateTime.Format("2006-01-02 15:04:05"))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"mcolomerc/vvp2cli/pkg/api"
+	"mcolomerc/vvp2cli/pkg/manifest"
+
+	"github.com/spf13/cobra"
+)
+
+// applyCmd creates or updates resources from a manifest
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Create or update resources from a YAML/JSON manifest",
+	Long: `Create or update Ververica Platform resources declaratively.
+
+The manifest's kind field selects the resource type (Deployment, SessionCluster,
+SecretValue, DeploymentTarget, Namespace, DeploymentDefaults). Resources that do
+not exist are created, existing ones are updated, and resources that already
+match the manifest are left untouched.
+
+The file may contain several "---" separated YAML documents or a JSON list,
+and may also be a directory of manifests (-R to recurse) or "-" for stdin.
+Objects are applied in dependency order: Namespace, DeploymentTarget,
+SecretValue, SessionCluster, DeploymentDefaults, then Deployment.`,
+	Example: `  vvp2 apply -f examples/mydeployment.yaml
+  vvp2 apply -f examples/sessioncluster.yaml -n default
+  vvp2 apply -f manifests/ -R
+  cat all.yaml | vvp2 apply -f -`,
+	RunE: runApply,
+}
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+
+	applyCmd.Flags().StringP("file", "f", "", "Path to manifest YAML/JSON file, directory, or - for stdin (required)")
+	applyCmd.Flags().BoolP("recursive", "R", false, "Process the directory used in -f recursively")
+	applyCmd.Flags().StringP("namespace", "n", "", "Namespace for namespaced resources without metadata.namespace")
+	applyCmd.MarkFlagRequired("file")
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	filename, _ := cmd.Flags().GetString("file")
+	recursive, _ := cmd.Flags().GetBool("recursive")
+	flagNamespace, _ := cmd.Flags().GetString("namespace")
+
+	objs, err := manifest.Read(filename, recursive)
+	if err != nil {
+		return err
+	}
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	// Keep going after a failure so one bad object doesn't hide the others
+	failed := 0
+	for _, obj := range objs {
+		result, err := applyObject(cmd.Context(), client, obj, flagNamespace)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to apply %s: %v\n", obj, err)
+			failed++
+			continue
+		}
+		fmt.Printf("%s %s\n", obj, result)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to apply %d of %d objects", failed, len(objs))
+	}
+	return nil
+}
+
+// applyObject creates or updates a single manifest object and reports
+// whether it was created, configured or unchanged.
+func applyObject(ctx context.Context, client *api.Client, obj *manifest.Object, flagNamespace string) (string, error) {
+	rk, err := lookupResourceKind(obj)
+	if err != nil {
+		return "", err
+	}
+
+	ns := ""
+	if rk.namespaced {
+		if ns, err = manifestNamespace(obj, flagNamespace); err != nil {
+			return "", err
+		}
+	}
+
+	desired := rk.newObject()
+	if err := obj.Into(desired); err != nil {
+		return "", err
+	}
+
+	live, err := rk.get(ctx, client, ns, obj.Name)
+	if err != nil {
+		if !api.IsNotFound(err) {
+			return "", err
+		}
+		err := rk.create(ctx, client, ns, desired)
+		if err == nil {
+			return "created", nil
+		}
+		// Someone else created it in the meantime; fall through to an update
+		if !api.IsConflict(err) {
+			return "", err
+		}
+		if err := rk.update(ctx, client, ns, obj.Name, desired); err != nil {
+			return "", err
+		}
+		return "configured", nil
+	}
+
+	unchanged, err := manifest.Equal(obj, desired, live)
+	if err != nil {
+		return "", err
+	}
+	if unchanged {
+		return "unchanged", nil
+	}
+
+	if err := rk.update(ctx, client, ns, obj.Name, desired); err != nil {
+		return "", err
+	}
+	return "configured", nil
+}
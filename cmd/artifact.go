@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"mcolomerc/vvp2cli/pkg/api"
+
+	"github.com/spf13/cobra"
+)
+
+var artifactCmd = &cobra.Command{
+	Use:     "artifact",
+	Aliases: []string{"artifacts", "art"},
+	Short:   "Manage VVP artifacts",
+	Long:    `Upload, list, download, and delete files (such as job JARs) in the artifact storage of a namespace.`,
+}
+
+var artifactUploadCmd = &cobra.Command{
+	Use:   "upload [file]",
+	Short: "Upload a file to the artifact storage",
+	Long: `Upload a local file, typically a job JAR, to the artifact storage of a namespace.
+
+The printed URI can be used as spec.template.spec.artifact.jarUri of a deployment.`,
+	Example: `  vvp2 artifact upload ./target/job.jar -n analytics
+  vvp2 artifact upload ./target/job-1.0-SNAPSHOT.jar --name job.jar`,
+	Args: cobra.ExactArgs(1),
+	RunE: runArtifactUpload,
+}
+
+var artifactListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List artifacts in a namespace",
+	RunE:    runArtifactList,
+}
+
+var artifactDownloadCmd = &cobra.Command{
+	Use:   "download [filename]",
+	Short: "Download an artifact",
+	Example: `  vvp2 artifact download job.jar
+  vvp2 artifact download job.jar --output-file /tmp/job.jar
+  vvp2 artifact download job.jar --output-file - > job.jar`,
+	Args: cobra.ExactArgs(1),
+	RunE: runArtifactDownload,
+}
+
+var artifactDeleteCmd = &cobra.Command{
+	Use:     "delete [filename]",
+	Aliases: []string{"rm"},
+	Short:   "Delete an artifact",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runArtifactDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(artifactCmd)
+
+	artifactCmd.AddCommand(artifactUploadCmd)
+	artifactCmd.AddCommand(artifactListCmd)
+	artifactCmd.AddCommand(artifactDownloadCmd)
+	artifactCmd.AddCommand(artifactDeleteCmd)
+
+	// Add flags
+	artifactUploadCmd.Flags().StringP("namespace", "n", "", "Namespace")
+	artifactUploadCmd.Flags().String("name", "", "Filename to store the artifact as (defaults to the local file name)")
+
+	artifactListCmd.Flags().StringP("namespace", "n", "", "Namespace")
+
+	artifactDownloadCmd.Flags().StringP("namespace", "n", "", "Namespace")
+	artifactDownloadCmd.Flags().StringP("output-file", "O", "", "Where to write the artifact (defaults to the filename in the current directory, - for stdout)")
+
+	artifactDeleteCmd.Flags().StringP("namespace", "n", "", "Namespace")
+}
+
+func runArtifactUpload(cmd *cobra.Command, args []string) error {
+	namespace, err := resolveNamespace(cmd)
+	if err != nil {
+		return err
+	}
+
+	name, _ := cmd.Flags().GetString("name")
+	if name == "" {
+		name = filepath.Base(args[0])
+	}
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	artifact, err := uploadArtifactFile(cmd.Context(), client, namespace, args[0], name)
+	if err != nil {
+		return err
+	}
+
+	if !isTableOutput() {
+		return printArtifact(artifact)
+	}
+	fmt.Printf("Artifact '%s' uploaded successfully\n", artifact.Filename)
+	if artifact.URI != "" {
+		fmt.Printf("URI: %s\n", artifact.URI)
+	}
+	return nil
+}
+
+// uploadArtifactFile uploads the local file at path as name, reporting progress
+func uploadArtifactFile(ctx context.Context, client *api.Client, namespace, path, name string) (*api.ArtifactFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", path)
+	}
+
+	// The progress counts the request body, which adds a few hundred bytes of
+	// multipart framing to the file
+	p := newProgress("Uploading "+name, info.Size())
+	artifact, err := client.UploadArtifactContext(ctx, namespace, name, file, p)
+	p.Finish()
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload %s: %w", path, err)
+	}
+	return artifact, nil
+}
+
+// uploadJar uploads a local JAR under a name that includes its content hash
+// and returns the artifact URI. Identical files are only uploaded once.
+func uploadJar(ctx context.Context, client *api.Client, namespace, path string) (string, error) {
+	name, err := contentAddressedName(path)
+	if err != nil {
+		return "", err
+	}
+
+	artifact, err := client.GetArtifactContext(ctx, namespace, name)
+	switch {
+	case err == nil:
+		fmt.Fprintf(os.Stderr, "Artifact '%s' is already uploaded, skipping upload\n", name)
+	case api.IsNotFound(err):
+		if artifact, err = uploadArtifactFile(ctx, client, namespace, path, name); err != nil {
+			return "", err
+		}
+		fmt.Fprintf(os.Stderr, "Artifact '%s' uploaded successfully\n", name)
+	default:
+		return "", fmt.Errorf("failed to look up artifact %s: %w", name, err)
+	}
+
+	if artifact.URI == "" {
+		return "", fmt.Errorf("the artifact storage returned no URI for %s", name)
+	}
+	return artifact.URI, nil
+}
+
+// contentAddressedName returns the file name of path with the first 12 hex
+// digits of its SHA-256 digest inserted before the extension, e.g.
+// job-3f2a9c1b7e4d.jar
+func contentAddressedName(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	digest := hex.EncodeToString(hash.Sum(nil))[:12]
+
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	return fmt.Sprintf("%s-%s%s", strings.TrimSuffix(base, ext), digest, ext), nil
+}
+
+func runArtifactList(cmd *cobra.Command, args []string) error {
+	namespace, err := resolveNamespace(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	artifacts, err := client.ListArtifactsContext(cmd.Context(), namespace)
+	if err != nil {
+		return err
+	}
+
+	return printArtifacts(artifacts.Artifacts)
+}
+
+func runArtifactDownload(cmd *cobra.Command, args []string) error {
+	filename := args[0]
+	namespace, err := resolveNamespace(cmd)
+	if err != nil {
+		return err
+	}
+
+	dest, _ := cmd.Flags().GetString("output-file")
+	if dest == "" {
+		dest = filepath.Base(filename)
+	}
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	if dest == "-" {
+		_, err := client.DownloadArtifactContext(cmd.Context(), namespace, filename, os.Stdout)
+		return err
+	}
+
+	// Download to a temporary file so a failed download never leaves a
+	// truncated artifact behind
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	p := newProgress("Downloading "+filename, 0)
+	n, err := client.DownloadArtifactContext(cmd.Context(), namespace, filename, io.MultiWriter(tmp, p))
+	p.Finish()
+	if err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	fmt.Printf("Artifact '%s' downloaded to %s (%s)\n", filename, dest, formatBytes(n))
+	return nil
+}
+
+func runArtifactDelete(cmd *cobra.Command, args []string) error {
+	filename := args[0]
+	namespace, err := resolveNamespace(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	if err := client.DeleteArtifactContext(cmd.Context(), namespace, filename); err != nil {
+		return err
+	}
+
+	fmt.Printf("Artifact '%s' deleted successfully\n", filename)
+	return nil
+}
+
+// Helper functions for printing artifacts
+func printArtifacts(artifacts []api.ArtifactFile) error {
+	return printOutput("Artifact", artifacts, func() error {
+		// Table format
+		if len(artifacts) == 0 {
+			fmt.Println("No artifacts found")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "FILENAME\tSIZE\tCREATED\tURI")
+		for _, artifact := range artifacts {
+			size := "-"
+			if artifact.Size > 0 {
+				size = formatBytes(artifact.Size)
+			}
+			created := "-"
+			if !artifact.CreateTime.IsZero() {
+				created = artifact.CreateTime.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", artifact.Filename, size, created, artifact.URI)
+		}
+		return w.Flush()
+	})
+}
+
+func printArtifact(artifact *api.ArtifactFile) error {
+	return printOutput("Artifact", artifact, func() error {
+		fmt.Printf("Filename: %s\n", artifact.Filename)
+		if artifact.URI != "" {
+			fmt.Printf("URI: %s\n", artifact.URI)
+		}
+		if artifact.Size > 0 {
+			fmt.Printf("Size: %s\n", formatBytes(artifact.Size))
+		}
+		if !artifact.CreateTime.IsZero() {
+			fmt.Printf("Created At: %s\n", artifact.CreateTime.Format("2006-01-02 15:04:05"))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"mcolomerc/vvp2cli/pkg/api"
+	"mcolomerc/vvp2cli/pkg/config"
+)
+
+// writeJar writes a JAR with the given content to a temporary directory
+func writeJar(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "job.jar")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write JAR: %v", err)
+	}
+	return path
+}
+
+// artifactServer serves an artifact storage holding the given files and
+// records the names of uploaded files
+func artifactServer(t *testing.T, stored ...string) (*api.Client, *[]string) {
+	t.Helper()
+	var uploads []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var filename string
+		switch {
+		case strings.HasSuffix(r.URL.Path, "artifacts:getMetadata"):
+			filename = r.URL.Query().Get("filename")
+			found := false
+			for _, name := range stored {
+				found = found || name == filename
+			}
+			if !found {
+				http.Error(w, `{"reason": "NotFound"}`, http.StatusNotFound)
+				return
+			}
+		case strings.HasSuffix(r.URL.Path, "artifacts:upload"):
+			_, header, err := r.FormFile("file")
+			if err != nil {
+				t.Errorf("Expected multipart file: %v", err)
+				return
+			}
+			filename = header.Filename
+			uploads = append(uploads, filename)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]api.ArtifactFile{
+			"artifact": {Filename: filename, URI: "s3://vvp/artifacts/namespaces/default/" + filename},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := api.NewClient(&config.Config{API: config.APIConfig{URL: server.URL}})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return client, &uploads
+}
+
+func TestContentAddressedName(t *testing.T) {
+	path := writeJar(t, "jar bytes")
+	digest := sha256.Sum256([]byte("jar bytes"))
+	want := "job-" + hex.EncodeToString(digest[:])[:12] + ".jar"
+
+	for i := 0; i < 2; i++ {
+		name, err := contentAddressedName(path)
+		if err != nil {
+			t.Fatalf("Failed to hash JAR: %v", err)
+		}
+		if name != want {
+			t.Errorf("Expected name '%s', got '%s'", want, name)
+		}
+	}
+
+	other, err := contentAddressedName(writeJar(t, "other bytes"))
+	if err != nil {
+		t.Fatalf("Failed to hash JAR: %v", err)
+	}
+	if other == want {
+		t.Error("Expected a different name for different content")
+	}
+}
+
+func TestUploadJarSkipsStoredArtifact(t *testing.T) {
+	path := writeJar(t, "jar bytes")
+	name, _ := contentAddressedName(path)
+	client, uploads := artifactServer(t, name)
+
+	uri, err := uploadJar(context.Background(), client, "default", path)
+	if err != nil {
+		t.Fatalf("Failed to upload JAR: %v", err)
+	}
+	if len(*uploads) != 0 {
+		t.Errorf("Expected the stored artifact not to be uploaded again, uploaded %v", *uploads)
+	}
+	if uri != "s3://vvp/artifacts/namespaces/default/"+name {
+		t.Errorf("Unexpected URI '%s'", uri)
+	}
+}
+
+func TestUploadJarUploadsMissingArtifact(t *testing.T) {
+	path := writeJar(t, "jar bytes")
+	name, _ := contentAddressedName(path)
+	client, uploads := artifactServer(t)
+
+	uri, err := uploadJar(context.Background(), client, "default", path)
+	if err != nil {
+		t.Fatalf("Failed to upload JAR: %v", err)
+	}
+	if len(*uploads) != 1 || (*uploads)[0] != name {
+		t.Errorf("Expected %s to be uploaded, uploaded %v", name, *uploads)
+	}
+	if uri != "s3://vvp/artifacts/namespaces/default/"+name {
+		t.Errorf("Unexpected URI '%s'", uri)
+	}
+}
+
+func TestSetDeploymentJar(t *testing.T) {
+	saved := deploymentJar
+	t.Cleanup(func() { deploymentJar = saved })
+	deploymentJar = writeJar(t, "jar bytes")
+	name, _ := contentAddressedName(deploymentJar)
+	client, uploads := artifactServer(t)
+
+	deployment := &api.Deployment{Metadata: api.DeploymentMetadata{Name: "job"}}
+	if err := setDeploymentJar(context.Background(), client, "default", deployment); err != nil {
+		t.Fatalf("Failed to set JAR: %v", err)
+	}
+	artifact := deployment.Spec.Template.Spec.Artifact
+	if artifact.Kind != "JAR" {
+		t.Errorf("Expected artifact kind to default to JAR, got '%s'", artifact.Kind)
+	}
+	if artifact.JarURI != "s3://vvp/artifacts/namespaces/default/"+name {
+		t.Errorf("Unexpected jarUri '%s'", artifact.JarURI)
+	}
+
+	sql := &api.Deployment{Metadata: api.DeploymentMetadata{Name: "sql-job"}}
+	sql.Spec.Template.Spec.Artifact.Kind = "SQLSCRIPT"
+	*uploads = nil
+	if err := setDeploymentJar(context.Background(), client, "default", sql); err == nil {
+		t.Error("Expected a SQLSCRIPT artifact to be rejected")
+	}
+	if len(*uploads) != 0 {
+		t.Errorf("Expected nothing to be uploaded for a rejected deployment, uploaded %v", *uploads)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strings"
 
+	"mcolomerc/vvp2cli/pkg/config"
+
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
@@ -57,11 +59,47 @@ var configPathCmd = &cobra.Command{
 	RunE:  runConfigPath,
 }
 
+// configSetCmd sets a single configuration value
+var configSetCmd = &cobra.Command{
+	Use:   "set [key] [value]",
+	Short: "Set a configuration value",
+	Long: `Set a configuration value in the configuration file without prompting.
+Comments and unknown keys in the file are preserved, and values are validated.
+
+Supported keys: ` + strings.Join(config.SettingKeys(), ", "),
+	Example: `  vvp2 config set api.url https://vvp.example.com
+  vvp2 config set output.format json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runConfigSet,
+}
+
+// configGetCmd prints a single configuration value
+var configGetCmd = &cobra.Command{
+	Use:     "get [key]",
+	Short:   "Print a configuration value",
+	Long:    `Print a configuration value from the configuration file. Exits with an error if the key is not set.`,
+	Example: `  vvp2 config get default.namespace`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runConfigGet,
+}
+
+// configUnsetCmd removes a configuration value
+var configUnsetCmd = &cobra.Command{
+	Use:     "unset [key]",
+	Short:   "Remove a configuration value",
+	Example: `  vvp2 config unset api.token`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runConfigUnset,
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
 	configCmd.AddCommand(configInitCmd)
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configPathCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configUnsetCmd)
 
 	// Flags for config init
 	configInitCmd.Flags().BoolP("force", "f", false, "Overwrite existing configuration file")
@@ -208,6 +246,67 @@ func runConfigPath(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	key, value := args[0], args[1]
+	file, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	if key == "current-context" && !file.HasContext(value) {
+		return fmt.Errorf("context %q not found in %s", value, file.Path)
+	}
+	if err := file.Set(key, value); err != nil {
+		return err
+	}
+	if err := file.Save(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Set %s in %s\n", key, file.Path)
+	return nil
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	file, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	value, ok, err := file.Get(args[0])
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%s is not set in %s", args[0], file.Path)
+	}
+
+	fmt.Println(value)
+	return nil
+}
+
+func runConfigUnset(cmd *cobra.Command, args []string) error {
+	file, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	removed, err := file.Unset(args[0])
+	if err != nil {
+		return err
+	}
+	if !removed {
+		fmt.Printf("%s is not set in %s\n", args[0], file.Path)
+		return nil
+	}
+	if err := file.Save(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Unset %s in %s\n", args[0], file.Path)
+	return nil
+}
+
 func getConfigPath() (string, error) {
 	if cfgFile != "" {
 		return cfgFile, nil
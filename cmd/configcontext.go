@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"mcolomerc/vvp2cli/pkg/config"
+
+	"github.com/spf13/cobra"
+)
+
+// configUseContextCmd switches the current context
+var configUseContextCmd = &cobra.Command{
+	Use:   "use-context [name]",
+	Short: "Set the current context",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigUseContext,
+}
+
+// configGetContextsCmd lists the contexts in the configuration file
+var configGetContextsCmd = &cobra.Command{
+	Use:   "get-contexts",
+	Short: "List the contexts in the configuration file",
+	RunE:  runConfigGetContexts,
+}
+
+// configSetContextCmd creates or updates a context
+var configSetContextCmd = &cobra.Command{
+	Use:   "set-context [name]",
+	Short: "Create or update a context",
+	Long: `Create a context, or update the given fields of an existing one.
+Only the flags that are passed are written; other fields are left unchanged.`,
+	Example: `  vvp2 config set-context prod --url https://vvp.example.com --token $TOKEN --namespace analytics
+  vvp2 config set-context dev --insecure --output-format yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigSetContext,
+}
+
+// configDeleteContextCmd removes a context
+var configDeleteContextCmd = &cobra.Command{
+	Use:   "delete-context [name]",
+	Short: "Delete a context",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigDeleteContext,
+}
+
+// contextFlags maps set-context flags to setting paths within a context
+var contextFlags = []struct {
+	flag string
+	path string
+}{
+	{"url", "api.url"},
+	{"token", "api.token"},
+	{"insecure", "api.insecure"},
+	{"ca-file", "api.caFile"},
+	{"client-cert", "api.clientCert"},
+	{"client-key", "api.clientKey"},
+	{"namespace", "default.namespace"},
+	{"output-format", "output.format"},
+}
+
+func init() {
+	configCmd.AddCommand(configUseContextCmd)
+	configCmd.AddCommand(configGetContextsCmd)
+	configCmd.AddCommand(configSetContextCmd)
+	configCmd.AddCommand(configDeleteContextCmd)
+
+	configSetContextCmd.Flags().String("url", "", "Ververica Platform API URL")
+	configSetContextCmd.Flags().String("token", "", "API authentication token")
+	configSetContextCmd.Flags().Bool("insecure", false, "Skip TLS certificate verification")
+	configSetContextCmd.Flags().String("ca-file", "", "PEM bundle of CA certificates to trust")
+	configSetContextCmd.Flags().String("client-cert", "", "Client certificate (PEM) for mutual TLS")
+	configSetContextCmd.Flags().String("client-key", "", "Client private key (PEM) for mutual TLS")
+	configSetContextCmd.Flags().String("namespace", "", "Default namespace")
+	configSetContextCmd.Flags().String("output-format", "", "Output format (table, json, yaml)")
+	configSetContextCmd.Flags().Bool("current", false, "Also make this the current context")
+}
+
+// loadConfigFile loads the configuration file for editing
+func loadConfigFile() (*config.File, error) {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	return config.LoadFile(configPath)
+}
+
+func runConfigUseContext(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	file, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	if !file.HasContext(name) {
+		return fmt.Errorf("context %q not found in %s", name, file.Path)
+	}
+
+	file.SetCurrentContext(name)
+	if err := file.Save(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Switched to context %q\n", name)
+	return nil
+}
+
+func runConfigGetContexts(cmd *cobra.Command, args []string) error {
+	file, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	contexts, err := file.Contexts()
+	if err != nil {
+		return err
+	}
+	if len(contexts) == 0 {
+		fmt.Println("No contexts found. Create one with 'vvp2 config set-context'.")
+		return nil
+	}
+
+	current := file.CurrentContext()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "CURRENT\tNAME\tAPI URL\tNAMESPACE\tOUTPUT")
+	for _, ctx := range contexts {
+		marker := ""
+		if ctx.Name == current {
+			marker = "*"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			marker,
+			ctx.Name,
+			ctx.API.URL,
+			ctx.Default.Namespace,
+			ctx.Output.Format,
+		)
+	}
+	return w.Flush()
+}
+
+func runConfigSetContext(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	values := map[string]string{}
+	for _, f := range contextFlags {
+		if cmd.Flags().Changed(f.flag) {
+			values[f.path] = cmd.Flags().Lookup(f.flag).Value.String()
+		}
+	}
+
+	file, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	existed := file.HasContext(name)
+	if err := file.SetContext(name, values); err != nil {
+		return err
+	}
+	if current, _ := cmd.Flags().GetBool("current"); current {
+		file.SetCurrentContext(name)
+	}
+	if err := file.Save(); err != nil {
+		return err
+	}
+
+	if existed {
+		fmt.Printf("Context %q modified\n", name)
+	} else {
+		fmt.Printf("Context %q created\n", name)
+	}
+	return nil
+}
+
+func runConfigDeleteContext(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	file, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	if !file.DeleteContext(name) {
+		return fmt.Errorf("context %q not found in %s", name, file.Path)
+	}
+
+	wasCurrent := file.CurrentContext() == name
+	if wasCurrent {
+		file.SetCurrentContext("")
+	}
+	if err := file.Save(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Context %q deleted\n", name)
+	if wasCurrent {
+		fmt.Println("Note: it was the current context; current-context has been unset.")
+	}
+	return nil
+}
@@ -1,12 +1,14 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"os"
 	"text/tabwriter"
+	"time"
 
 	"mcolomerc/vvp2cli/pkg/api"
+	"mcolomerc/vvp2cli/pkg/manifest"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -15,7 +17,11 @@ import (
 var (
 	deploymentNamespace string
 	deploymentFile      string
+	deploymentJar       string
+	deploymentRecursive bool
 	deploymentState     string
+	deploymentWait      bool
+	deploymentTimeout   time.Duration
 )
 
 // deploymentCmd represents the deployment command
@@ -46,23 +52,42 @@ var getDeploymentCmd = &cobra.Command{
 var createDeploymentCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new deployment from a YAML/JSON file",
-	RunE:  runCreateDeployment,
+	Long: `Create a new deployment from a YAML/JSON file.
+
+With --jar, the local JAR is uploaded to the artifact storage of the namespace
+and spec.template.spec.artifact.jarUri is set to the uploaded artifact. The
+stored file name contains a hash of the JAR, so unchanged JARs are not
+uploaded again.`,
+	Example: `  vvp2 deployment create -f deployment.yaml --jar ./target/job.jar`,
+	RunE:    runCreateDeployment,
 }
 
 // updateDeploymentCmd updates a deployment
 var updateDeploymentCmd = &cobra.Command{
 	Use:   "update [name]",
 	Short: "Update an existing deployment",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runUpdateDeployment,
+	Long: `Update an existing deployment from a YAML/JSON file.
+
+With --jar, the local JAR is uploaded (unless an identical one already is)
+and spec.template.spec.artifact.jarUri is set to the uploaded artifact.`,
+	Example: `  vvp2 deployment update my-job -f deployment.yaml --jar ./target/job.jar`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runUpdateDeployment,
 }
 
 // deleteDeploymentCmd deletes a deployment
 var deleteDeploymentCmd = &cobra.Command{
 	Use:   "delete [name]",
 	Short: "Delete a deployment",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runDeleteDeployment,
+	Long: `Delete a deployment.
+
+With --force, a running deployment is cancelled first and the command waits
+until the job has left the RUNNING and TRANSITIONING states before deleting.
+With --delete-savepoints, the savepoints of the deployment are deleted too.`,
+	Example: `  vvp2 deployment delete my-job --force --timeout 2m
+  vvp2 deployment delete my-job --force --delete-savepoints`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDeleteDeployment,
 }
 
 // startDeploymentCmd starts a deployment
@@ -71,7 +96,7 @@ var startDeploymentCmd = &cobra.Command{
 	Short: "Start a deployment",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runUpdateDeploymentState(args[0], "RUNNING")
+		return runUpdateDeploymentState(cmd.Context(), args[0], "RUNNING")
 	},
 }
 
@@ -81,7 +106,7 @@ var stopDeploymentCmd = &cobra.Command{
 	Short: "Stop a deployment",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runUpdateDeploymentState(args[0], "CANCELLED")
+		return runUpdateDeploymentState(cmd.Context(), args[0], "CANCELLED")
 	},
 }
 
@@ -91,10 +116,21 @@ var suspendDeploymentCmd = &cobra.Command{
 	Short: "Suspend a deployment",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runUpdateDeploymentState(args[0], "SUSPENDED")
+		return runUpdateDeploymentState(cmd.Context(), args[0], "SUSPENDED")
 	},
 }
 
+// waitDeploymentCmd waits for a deployment to reach a state
+var waitDeploymentCmd = &cobra.Command{
+	Use:   "wait [name]",
+	Short: "Wait for a deployment to reach a state",
+	Long: `Poll a deployment until status.state reaches the requested state.
+Fails immediately if the deployment ends up FAILED (unless waiting for FAILED).`,
+	Example: `  vvp2 deployment wait my-job --for=state=RUNNING --timeout 10m`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runWaitDeployment,
+}
+
 func init() {
 	rootCmd.AddCommand(deploymentCmd)
 	deploymentCmd.AddCommand(listDeploymentsCmd)
@@ -105,18 +141,34 @@ func init() {
 	deploymentCmd.AddCommand(startDeploymentCmd)
 	deploymentCmd.AddCommand(stopDeploymentCmd)
 	deploymentCmd.AddCommand(suspendDeploymentCmd)
+	deploymentCmd.AddCommand(waitDeploymentCmd)
 
 	// Flags for deployment commands
 	deploymentCmd.PersistentFlags().StringVarP(&deploymentNamespace, "namespace", "n", "", "Namespace (defaults to config if not set)")
 
-	createDeploymentCmd.Flags().StringVarP(&deploymentFile, "file", "f", "", "Path to deployment YAML/JSON file (required)")
+	addSelectorFlag(listDeploymentsCmd)
+
+	createDeploymentCmd.Flags().StringVarP(&deploymentFile, "file", "f", "", "Path to deployment YAML/JSON file, directory, or - for stdin (required)")
+	createDeploymentCmd.Flags().BoolVarP(&deploymentRecursive, "recursive", "R", false, "Process the directory used in -f recursively")
+	createDeploymentCmd.Flags().StringVar(&deploymentJar, "jar", "", "Local JAR to upload and use as the artifact jarUri")
 	createDeploymentCmd.MarkFlagRequired("file")
 
-	updateDeploymentCmd.Flags().StringVarP(&deploymentFile, "file", "f", "", "Path to deployment YAML/JSON file (required)")
+	updateDeploymentCmd.Flags().StringVarP(&deploymentFile, "file", "f", "", "Path to deployment YAML/JSON file, or - for stdin (required)")
+	updateDeploymentCmd.Flags().StringVar(&deploymentJar, "jar", "", "Local JAR to upload and use as the artifact jarUri")
 	updateDeploymentCmd.MarkFlagRequired("file")
 	
-	deleteDeploymentCmd.Flags().BoolP("force", "", false, "Force delete by cancelling the deployment first if needed")
+	deleteDeploymentCmd.Flags().BoolP("force", "", false, "Force delete by cancelling the deployment and waiting for the job to stop")
+	deleteDeploymentCmd.Flags().Duration("timeout", defaultWaitTimeout, "Maximum time to wait for the job to stop with --force")
+	deleteDeploymentCmd.Flags().Bool("delete-savepoints", false, "Also delete the savepoints of the deployment")
 	updateDeploymentCmd.MarkFlagRequired("file")
+
+	for _, c := range []*cobra.Command{startDeploymentCmd, stopDeploymentCmd, suspendDeploymentCmd} {
+		c.Flags().BoolVar(&deploymentWait, "wait", false, "Wait until the deployment reaches the requested state")
+		c.Flags().DurationVar(&deploymentTimeout, "timeout", defaultWaitTimeout, "Maximum time to wait with --wait")
+	}
+
+	waitDeploymentCmd.Flags().String("for", "state=RUNNING", "Condition to wait for (state=<STATE>)")
+	waitDeploymentCmd.Flags().DurationVar(&deploymentTimeout, "timeout", defaultWaitTimeout, "Maximum time to wait")
 }
 
 func runListDeployments(cmd *cobra.Command, args []string) error {
@@ -129,8 +181,12 @@ func runListDeployments(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	selector, err := labelSelector(cmd)
+	if err != nil {
+		return err
+	}
 
-	deployments, err := client.ListDeployments(ns)
+	deployments, err := client.ListDeploymentsMatchingContext(cmd.Context(), ns, selector)
 	if err != nil {
 		return fmt.Errorf("failed to list deployments: %w", err)
 	}
@@ -155,7 +211,7 @@ func runGetDeployment(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	deployment, err := client.GetDeployment(ns, args[0])
+	deployment, err := client.GetDeploymentContext(cmd.Context(), ns, args[0])
 	if err != nil {
 		return fmt.Errorf("failed to get deployment: %w", err)
 	}
@@ -174,18 +230,29 @@ func runCreateDeployment(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	deployment, err := loadDeploymentFromFile(deploymentFile)
+	deployments, err := loadDeploymentsFromFile(deploymentFile, deploymentRecursive)
 	if err != nil {
 		return err
 	}
 
-	result, err := client.CreateDeployment(ns, deployment)
-	if err != nil {
-		return fmt.Errorf("failed to create deployment: %w", err)
+	if deploymentJar != "" {
+		if err := setDeploymentJar(cmd.Context(), client, ns, deployments...); err != nil {
+			return err
+		}
 	}
 
-	fmt.Printf("Deployment %s created successfully\n", result.Metadata.Name)
-	return printDeployment(result)
+	for _, deployment := range deployments {
+		result, err := client.CreateDeploymentContext(cmd.Context(), ns, deployment)
+		if err != nil {
+			return fmt.Errorf("failed to create deployment %s: %w", deployment.Metadata.Name, err)
+		}
+
+		fmt.Printf("Deployment %s created successfully\n", result.Metadata.Name)
+		if err := printDeployment(result); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func runUpdateDeployment(cmd *cobra.Command, args []string) error {
@@ -205,14 +272,20 @@ func runUpdateDeployment(cmd *cobra.Command, args []string) error {
 	}
 
 	// Preserve immutable fields: ensure name & namespace match existing resource and don't try to change them
-	existing, err := client.GetDeployment(ns, args[0])
+	existing, err := client.GetDeploymentContext(cmd.Context(), ns, args[0])
 	if err != nil {
 		return fmt.Errorf("failed to fetch existing deployment: %w", err)
 	}
 	deployment.Metadata.Name = existing.Metadata.Name
 	deployment.Metadata.Namespace = existing.Metadata.Namespace
 
-	result, err := client.UpdateDeployment(ns, args[0], deployment)
+	if deploymentJar != "" {
+		if err := setDeploymentJar(cmd.Context(), client, ns, deployment); err != nil {
+			return err
+		}
+	}
+
+	result, err := client.UpdateDeploymentContext(cmd.Context(), ns, args[0], deployment)
 	if err != nil {
 		return fmt.Errorf("failed to update deployment: %w", err)
 	}
@@ -221,6 +294,29 @@ func runUpdateDeployment(cmd *cobra.Command, args []string) error {
 	return printDeployment(result)
 }
 
+// setDeploymentJar uploads deploymentJar and points the artifact of each
+// deployment at it. Deployments with another kind of artifact are rejected
+// before anything is uploaded.
+func setDeploymentJar(ctx context.Context, client *api.Client, namespace string, deployments ...*api.Deployment) error {
+	for _, deployment := range deployments {
+		if kind := deployment.Spec.Template.Spec.Artifact.Kind; kind != "" && kind != "JAR" {
+			return fmt.Errorf("deployment %s has a %s artifact; --jar requires a JAR artifact", deployment.Metadata.Name, kind)
+		}
+	}
+
+	jarURI, err := uploadJar(ctx, client, namespace, deploymentJar)
+	if err != nil {
+		return err
+	}
+
+	for _, deployment := range deployments {
+		artifact := &deployment.Spec.Template.Spec.Artifact
+		artifact.Kind = "JAR"
+		artifact.JarURI = jarURI
+	}
+	return nil
+}
+
 func runDeleteDeployment(cmd *cobra.Command, args []string) error {
 	client, err := api.NewClient(GetConfig())
 	if err != nil {
@@ -232,36 +328,76 @@ func runDeleteDeployment(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	name := args[0]
 	force, _ := cmd.Flags().GetBool("force")
-	
-	// If force flag is set, try to cancel the deployment first
-	if force {
-		// Get current deployment state
-		deployment, err := client.GetDeployment(ns, args[0])
+	deleteSavepoints, _ := cmd.Flags().GetBool("delete-savepoints")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	var deployment *api.Deployment
+	if force || deleteSavepoints {
+		deployment, err = client.GetDeploymentContext(cmd.Context(), ns, name)
 		if err != nil {
 			return fmt.Errorf("failed to get deployment: %w", err)
 		}
-		
-		// If not already cancelled, cancel it first
+	}
+
+	// If force flag is set, cancel the deployment and wait for the job to stop
+	if force {
 		if deployment.Spec.State != "CANCELLED" {
-			fmt.Printf("Cancelling deployment %s before deletion...\n", args[0])
-			if _, err := client.UpdateDeploymentState(ns, args[0], "CANCELLED"); err != nil {
+			fmt.Printf("Cancelling deployment %s before deletion...\n", name)
+			if _, err := client.UpdateDeploymentStateContext(cmd.Context(), ns, name, "CANCELLED"); err != nil {
 				return fmt.Errorf("failed to cancel deployment: %w", err)
 			}
-			fmt.Printf("Deployment %s transitioned to CANCELLED state\n", args[0])
-			fmt.Printf("Note: The deployment may take some time to fully cancel. If deletion fails, wait a moment and try again.\n")
+		}
+
+		fmt.Printf("Waiting for deployment %s to stop...\n", name)
+		if _, err := client.WaitForDeploymentStoppedContext(cmd.Context(), ns, name, waitOptions(timeout)); err != nil {
+			return err
 		}
 	}
 
-	if err := client.DeleteDeployment(ns, args[0]); err != nil {
+	// Collect savepoints before the deployment is gone
+	var savepoints []api.Savepoint
+	if deleteSavepoints {
+		savepoints, err = client.ListDeploymentSavepointsContext(cmd.Context(), ns, deployment.Metadata.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list savepoints: %w", err)
+		}
+	}
+
+	if err := client.DeleteDeploymentContext(cmd.Context(), ns, name); err != nil {
 		return fmt.Errorf("failed to delete deployment: %w", err)
 	}
 
-	fmt.Printf("Deployment %s deleted successfully\n", args[0])
+	if !deleteSavepoints {
+		fmt.Printf("Deployment %s deleted successfully\n", name)
+		return nil
+	}
+
+	var deleted []string
+	failed := 0
+	for _, sp := range savepoints {
+		if err := client.DeleteSavepointContext(cmd.Context(), ns, sp.Metadata.ID); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to delete savepoint %s: %v\n", sp.Metadata.ID, err)
+			failed++
+			continue
+		}
+		deleted = append(deleted, sp.Metadata.ID)
+	}
+
+	fmt.Printf("Deleted deployment %s\n", name)
+	fmt.Printf("Deleted %d savepoint(s)\n", len(deleted))
+	for _, id := range deleted {
+		fmt.Printf("  - %s\n", id)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d savepoints", failed, len(savepoints))
+	}
 	return nil
 }
 
-func runUpdateDeploymentState(name, state string) error {
+func runUpdateDeploymentState(ctx context.Context, name, state string) error {
 	client, err := api.NewClient(GetConfig())
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %w", err)
@@ -272,48 +408,86 @@ func runUpdateDeploymentState(name, state string) error {
 		return err
 	}
 
-	result, err := client.UpdateDeploymentState(ns, name, state)
+	result, err := client.UpdateDeploymentStateContext(ctx, ns, name, state)
 	if err != nil {
 		return fmt.Errorf("failed to update deployment state: %w", err)
 	}
 
 	fmt.Printf("Deployment %s state updated to %s\n", name, state)
+
+	if deploymentWait {
+		fmt.Printf("Waiting for deployment %s to reach %s...\n", name, state)
+		result, err = client.WaitForDeploymentStateContext(ctx, ns, name, state, waitOptions(deploymentTimeout))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Deployment %s is %s\n", name, state)
+	}
 	return printDeployment(result)
 }
 
-func loadDeploymentFromFile(filename string) (*api.Deployment, error) {
-	data, err := os.ReadFile(filename)
+func runWaitDeployment(cmd *cobra.Command, args []string) error {
+	condition, _ := cmd.Flags().GetString("for")
+	state, err := parseWaitFor(condition)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+		return err
 	}
 
-	var deployment api.Deployment
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
 
-	// Try JSON first
-	if err := json.Unmarshal(data, &deployment); err != nil {
-		// Try YAML
-		if err := yaml.Unmarshal(data, &deployment); err != nil {
-			return nil, fmt.Errorf("failed to parse file as JSON or YAML: %w", err)
-		}
+	ns, err := effectiveDeploymentNamespace()
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.WaitForDeploymentStateContext(cmd.Context(), ns, args[0], state, waitOptions(deploymentTimeout)); err != nil {
+		return err
 	}
 
+	fmt.Printf("Deployment %s is %s\n", args[0], state)
+	return nil
+}
+
+func loadDeploymentFromFile(filename string) (*api.Deployment, error) {
+	obj, err := loadManifestObject(filename, manifest.KindDeployment)
+	if err != nil {
+		return nil, err
+	}
+
+	var deployment api.Deployment
+	if err := obj.Into(&deployment); err != nil {
+		return nil, err
+	}
 	return &deployment, nil
 }
 
-func printDeployments(deployments []api.Deployment) error {
-	if len(deployments) == 0 {
-		fmt.Println("No deployments found")
-		return nil
+func loadDeploymentsFromFile(filename string, recursive bool) ([]*api.Deployment, error) {
+	objs, err := loadManifestObjects(filename, manifest.KindDeployment, recursive)
+	if err != nil {
+		return nil, err
 	}
 
-	format := GetConfig().GetOutputFormat()
+	deployments := make([]*api.Deployment, 0, len(objs))
+	for _, obj := range objs {
+		var deployment api.Deployment
+		if err := obj.Into(&deployment); err != nil {
+			return nil, err
+		}
+		deployments = append(deployments, &deployment)
+	}
+	return deployments, nil
+}
+
+func printDeployments(deployments []api.Deployment) error {
+	return printOutput(manifest.KindDeployment, deployments, func() error {
+		if len(deployments) == 0 {
+			fmt.Println("No deployments found")
+			return nil
+		}
 
-	switch format {
-	case "json":
-		return printJSON(deployments)
-	case "yaml":
-		return printYAML(deployments)
-	default:
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
 		fmt.Fprintln(w, "NAME\tNAMESPACE\tSTATE\tCREATED")
 		for _, d := range deployments {
@@ -330,26 +504,14 @@ func printDeployments(deployments []api.Deployment) error {
 				d.Metadata.CreatedAt.Format("2006-01-02 15:04:05"))
 		}
 		return w.Flush()
-	}
+	})
 }
 
 func printDeployment(deployment *api.Deployment) error {
-	format := GetConfig().GetOutputFormat()
-
-	switch format {
-	case "json":
-		return printJSON(deployment)
-	case "yaml":
-		return printYAML(deployment)
-	default:
+	// A deployment has too many fields for a table; show it as YAML
+	return printOutput(manifest.KindDeployment, deployment, func() error {
 		return printYAML(deployment)
-	}
-}
-
-func printJSON(v interface{}) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(v)
+	})
 }
 
 func printYAML(v interface{}) error {
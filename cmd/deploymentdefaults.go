@@ -1,14 +1,12 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 
 	"mcolomerc/vvp2cli/pkg/api"
+	"mcolomerc/vvp2cli/pkg/manifest"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -54,10 +52,10 @@ func init() {
 	// Flags
 	deploymentDefaultsCmd.PersistentFlags().StringVarP(&deploymentDefaultsNamespace, "namespace", "n", "", "Namespace (defaults to config if not set)")
 
-	replaceDeploymentDefaultsCmd.Flags().StringVarP(&deploymentDefaultsFile, "file", "f", "", "Path to deployment defaults YAML/JSON file (required)")
+	replaceDeploymentDefaultsCmd.Flags().StringVarP(&deploymentDefaultsFile, "file", "f", "", "Path to deployment defaults YAML/JSON file, or - for stdin (required)")
 	replaceDeploymentDefaultsCmd.MarkFlagRequired("file")
 
-	updateDeploymentDefaultsCmd.Flags().StringVarP(&deploymentDefaultsFile, "file", "f", "", "Path to SecretValue YAML/JSON file (required)")
+	updateDeploymentDefaultsCmd.Flags().StringVarP(&deploymentDefaultsFile, "file", "f", "", "Path to SecretValue YAML/JSON file, or - for stdin (required)")
 	updateDeploymentDefaultsCmd.MarkFlagRequired("file")
 }
 
@@ -72,7 +70,7 @@ func runGetDeploymentDefaults(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	dd, err := client.GetDeploymentDefaults(ns)
+	dd, err := client.GetDeploymentDefaultsContext(cmd.Context(), ns)
 	if err != nil {
 		return fmt.Errorf("failed to get deployment defaults: %w", err)
 	}
@@ -96,7 +94,7 @@ func runReplaceDeploymentDefaults(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	res, err := client.ReplaceDeploymentDefaults(ns, dd)
+	res, err := client.ReplaceDeploymentDefaultsContext(cmd.Context(), ns, dd)
 	if err != nil {
 		return fmt.Errorf("failed to replace deployment defaults: %w", err)
 	}
@@ -121,7 +119,7 @@ func runUpdateDeploymentDefaults(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	res, err := client.UpdateDeploymentDefaults(ns, sv)
+	res, err := client.UpdateDeploymentDefaultsContext(cmd.Context(), ns, sv)
 	if err != nil {
 		return fmt.Errorf("failed to update deployment defaults: %w", err)
 	}
@@ -131,52 +129,53 @@ func runUpdateDeploymentDefaults(cmd *cobra.Command, args []string) error {
 }
 
 func loadDeploymentDefaultsFromFile(filename string) (*api.DeploymentDefaults, error) {
-	data, err := os.ReadFile(filename)
+	obj, err := loadManifestObject(filename, manifest.KindDeploymentDefaults)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+		return nil, err
 	}
 
 	var dd api.DeploymentDefaults
-	if err := json.Unmarshal(data, &dd); err != nil {
-		if err := yaml.Unmarshal(data, &dd); err != nil {
-			return nil, fmt.Errorf("failed to parse file as JSON or YAML: %w", err)
-		}
+	if err := obj.Into(&dd); err != nil {
+		return nil, err
 	}
 	return &dd, nil
 }
 
 func loadSecretValueFromFile(filename string) (*api.SecretValue, error) {
-	data, err := os.ReadFile(filename)
+	obj, err := loadManifestObject(filename, manifest.KindSecretValue)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+		return nil, err
 	}
 
 	var sv api.SecretValue
-	if err := json.Unmarshal(data, &sv); err != nil {
-		if err := yaml.Unmarshal(data, &sv); err != nil {
-			return nil, fmt.Errorf("failed to parse file as JSON or YAML: %w", err)
-		}
+	if err := obj.Into(&sv); err != nil {
+		return nil, err
 	}
 	return &sv, nil
 }
 
-func printDeploymentDefaults(dd *api.DeploymentDefaults) error {
-	format := GetConfig().GetOutputFormat()
-	switch format {
-	case "json":
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(dd)
-	case "yaml":
-		enc := yaml.NewEncoder(os.Stdout)
-		enc.SetIndent(2)
-		return enc.Encode(dd)
-	default:
-		// For table, print YAML for rich structure
-		enc := yaml.NewEncoder(os.Stdout)
-		enc.SetIndent(2)
-		return enc.Encode(dd)
+func loadSecretValuesFromFile(filename string, recursive bool) ([]*api.SecretValue, error) {
+	objs, err := loadManifestObjects(filename, manifest.KindSecretValue, recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	secretValues := make([]*api.SecretValue, 0, len(objs))
+	for _, obj := range objs {
+		var sv api.SecretValue
+		if err := obj.Into(&sv); err != nil {
+			return nil, err
+		}
+		secretValues = append(secretValues, &sv)
 	}
+	return secretValues, nil
+}
+
+func printDeploymentDefaults(dd *api.DeploymentDefaults) error {
+	// For table, print YAML for rich structure
+	return printOutput(manifest.KindDeploymentDefaults, dd, func() error {
+		return printYAML(dd)
+	})
 }
 
 // effectiveDeploymentDefaultsNamespace determines the namespace to use for deployment-defaults commands
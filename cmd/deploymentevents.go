@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"mcolomerc/vvp2cli/pkg/api"
+	"mcolomerc/vvp2cli/pkg/printer"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// eventsDeploymentCmd shows the events of a deployment
+var eventsDeploymentCmd = &cobra.Command{
+	Use:   "events [name]",
+	Short: "Show the events of a deployment",
+	Long: `Show the events VVP recorded for a deployment, such as state transitions and
+the reasons jobs failed, oldest first.
+
+--since limits the output to recent events and accepts a duration (1h, 30m)
+or an RFC 3339 timestamp. --follow keeps polling and prints new events as they
+arrive until interrupted; with -o json each event is printed on its own line.`,
+	Example: `  vvp2 deployment events my-job
+  vvp2 deployment events my-job --since 1h
+  vvp2 deployment events my-job --job 5a3f... --follow`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDeploymentEvents,
+}
+
+func init() {
+	deploymentCmd.AddCommand(eventsDeploymentCmd)
+
+	eventsDeploymentCmd.Flags().String("since", "", "Only show events newer than a duration (e.g. 1h) or RFC 3339 timestamp")
+	eventsDeploymentCmd.Flags().String("job", "", "Only show events of this job ID")
+	eventsDeploymentCmd.Flags().BoolP("follow", "f", false, "Keep polling for new events")
+	eventsDeploymentCmd.Flags().Duration("interval", api.DefaultPollInterval, "Polling interval with --follow")
+}
+
+func runDeploymentEvents(cmd *cobra.Command, args []string) error {
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	since, err := parseSince(sinceFlag, time.Now())
+	if err != nil {
+		return err
+	}
+	jobID, _ := cmd.Flags().GetString("job")
+	follow, _ := cmd.Flags().GetBool("follow")
+	interval, _ := cmd.Flags().GetDuration("interval")
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	ns, err := effectiveDeploymentNamespace()
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	deployment, err := client.GetDeploymentContext(ctx, ns, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get deployment: %w", err)
+	}
+	opts := api.EventListOptions{DeploymentID: deployment.Metadata.ID, JobID: jobID}
+
+	events, err := client.ListEventsContext(ctx, ns, opts)
+	if err != nil {
+		return err
+	}
+	events = eventsSince(events, since)
+
+	if !follow {
+		return printEvents(events)
+	}
+	return followEvents(ctx, client, ns, opts, events, since, interval)
+}
+
+// followEvents prints events and then polls for new ones until ctx is done
+func followEvents(ctx context.Context, client *api.Client, ns string, opts api.EventListOptions, events []api.Event, since time.Time, interval time.Duration) error {
+	seen := make(map[string]bool)
+	p := &eventPrinter{format: outputFormat(), follow: true}
+	for {
+		var fresh []api.Event
+		for _, event := range events {
+			if key := eventKey(event); !seen[key] {
+				seen[key] = true
+				fresh = append(fresh, event)
+			}
+		}
+		if err := p.print(fresh); err != nil {
+			return err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			// Interrupting --follow is the normal way to stop it
+			return nil
+		case <-timer.C:
+		}
+
+		var err error
+		events, err = client.ListEventsContext(ctx, ns, opts)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		events = eventsSince(events, since)
+	}
+}
+
+// eventKey identifies an event across polls
+func eventKey(event api.Event) string {
+	if event.Metadata.ID != "" {
+		return event.Metadata.ID
+	}
+	return event.Time().String() + "/" + event.Metadata.JobID + "/" + event.Spec.Message
+}
+
+// parseSince parses a --since value relative to now. An empty value means no limit.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: expected a duration (e.g. 1h) or RFC 3339 timestamp", value)
+}
+
+// eventsSince drops events older than since
+func eventsSince(events []api.Event, since time.Time) []api.Event {
+	if since.IsZero() {
+		return events
+	}
+	filtered := events[:0]
+	for _, event := range events {
+		if !event.Time().Before(since) {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+func printEvents(events []api.Event) error {
+	return printOutput("", events, func() error {
+		if len(events) == 0 {
+			fmt.Println("No events found")
+			return nil
+		}
+		return (&eventPrinter{format: outputFormat()}).print(events)
+	})
+}
+
+// eventPrinter prints batches of events as they arrive: table rows under a
+// single header, one JSON/YAML document per event, or each batch in the
+// other -o formats. With follow set, table columns have fixed widths so that
+// rows of later batches line up with the first.
+type eventPrinter struct {
+	format      string
+	follow      bool
+	wroteHeader bool
+}
+
+// eventColumnWidths are the fixed widths of the TIME, JOB ID (a UUID) and
+// SEVERITY columns when following events
+var eventColumnWidths = []int{19, 36, 8}
+
+func (p *eventPrinter) print(events []api.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	f, err := printer.ParseFormat(p.format)
+	if err != nil {
+		return err
+	}
+	switch f.Name {
+	case printer.JSON:
+		for _, event := range events {
+			data, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+		}
+	case printer.YAML:
+		for _, event := range events {
+			data, err := yaml.Marshal(event)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("---\n%s", data)
+		}
+	case printer.Table:
+		rows := make([][]string, 0, len(events)+1)
+		if !p.wroteHeader {
+			rows = append(rows, []string{"TIME", "JOB ID", "SEVERITY", "MESSAGE"})
+			p.wroteHeader = true
+		}
+		for _, event := range events {
+			rows = append(rows, eventRow(event))
+		}
+		if p.follow {
+			for _, row := range rows {
+				fmt.Printf("%-*s   %-*s   %-*s   %s\n",
+					eventColumnWidths[0], row[0], eventColumnWidths[1], row[1], eventColumnWidths[2], row[2], row[3])
+			}
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		for _, row := range rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		w.Flush()
+	default:
+		// Print custom-columns headers only above the first batch
+		if err := printer.Print(os.Stdout, f, events, printer.Options{NoHeaders: p.wroteHeader}); err != nil {
+			return err
+		}
+		p.wroteHeader = true
+	}
+	return nil
+}
+
+// eventRow returns the table columns of an event
+func eventRow(event api.Event) []string {
+	timestamp := "-"
+	if t := event.Time(); !t.IsZero() {
+		timestamp = t.Local().Format("2006-01-02 15:04:05")
+	}
+	jobID := event.Metadata.JobID
+	if jobID == "" {
+		jobID = "-"
+	}
+	severity := event.Spec.Severity
+	if severity == "" {
+		severity = "-"
+	}
+	// Keep multi-line messages (e.g. stack traces) on one row
+	message := strings.Join(strings.Fields(event.Spec.Message), " ")
+	return []string{timestamp, jobID, severity, message}
+}
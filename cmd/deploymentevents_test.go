@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"mcolomerc/vvp2cli/pkg/api"
+)
+
+func TestEventPrinterFollowAlignsBatches(t *testing.T) {
+	p := &eventPrinter{format: "table", follow: true}
+	batches := [][]api.Event{
+		fixture[[]api.Event](t, `[{"metadata": {"id": "e-1"}, "spec": {"timestamp": "2026-01-02T10:00:05Z", "severity": "INFO", "message": "Deployment created"}}]`),
+		fixture[[]api.Event](t, `[{"metadata": {"id": "e-2", "jobId": "3f2c9a1e-8b7d-4c6a-9e5f-1a2b3c4d5e6f"}, "spec": {"timestamp": "2026-01-02T10:01:00Z", "severity": "WARNING", "message": "Job restarting"}}]`),
+	}
+
+	out := captureStdout(t, func() error {
+		for _, batch := range batches {
+			if err := p.print(batch); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "TIME") {
+		t.Fatalf("Expected one header and two rows, got:\n%s", out)
+	}
+	column := strings.Index(lines[0], "MESSAGE")
+	for _, line := range lines[1:] {
+		if !strings.HasPrefix(line[column:], "Deployment created") && !strings.HasPrefix(line[column:], "Job restarting") {
+			t.Errorf("Expected the message in the MESSAGE column, got:\n%s", out)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"mcolomerc/vvp2cli/pkg/api"
+
+	"github.com/spf13/cobra"
+)
+
+// logsDeploymentCmd prints Flink logs of a deployment's running job
+var logsDeploymentCmd = &cobra.Command{
+	Use:   "logs [name]",
+	Short: "Show the Flink logs of a deployment",
+	Long: `Show the JobManager log, or a TaskManager log with --taskmanager, of the
+deployment's running job. Logs are fetched through the Flink UI proxy of VVP,
+so the deployment must have a running job.
+
+--taskmanager accepts a TaskManager ID or a unique prefix of one; run with
+--taskmanager list to show the available TaskManagers.
+
+--follow polls for appended lines with HTTP range requests, and backs off
+while the log is idle.`,
+	Example: `  vvp2 deployment logs my-job --tail 100
+  vvp2 deployment logs my-job --taskmanager list
+  vvp2 deployment logs my-job --taskmanager 10.0.3.17:6122-6f3a2b --follow`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDeploymentLogs,
+}
+
+func init() {
+	deploymentCmd.AddCommand(logsDeploymentCmd)
+
+	logsDeploymentCmd.Flags().String("taskmanager", "", "Show the log of this TaskManager instead of the JobManager ('list' to list TaskManagers)")
+	logsDeploymentCmd.Flags().Int("tail", -1, "Number of lines to show from the end of the log (-1 for all)")
+	logsDeploymentCmd.Flags().BoolP("follow", "f", false, "Keep polling the log and print new lines")
+	logsDeploymentCmd.Flags().Duration("interval", api.DefaultPollInterval, "Polling interval with --follow (backs off while the log is idle)")
+}
+
+func runDeploymentLogs(cmd *cobra.Command, args []string) error {
+	taskManager, _ := cmd.Flags().GetString("taskmanager")
+	tail, _ := cmd.Flags().GetInt("tail")
+	follow, _ := cmd.Flags().GetBool("follow")
+	interval, _ := cmd.Flags().GetDuration("interval")
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	ns, err := effectiveDeploymentNamespace()
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	deployment, err := client.GetDeploymentContext(ctx, ns, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get deployment: %w", err)
+	}
+	job, err := client.CurrentDeploymentJobContext(ctx, ns, deployment.Metadata.ID)
+	if errors.Is(err, api.ErrNoRunningJob) {
+		state := ""
+		if deployment.Status != nil && deployment.Status.State != "" {
+			state = " (deployment is " + deployment.Status.State + ")"
+		}
+		return fmt.Errorf("deployment %s has no running job%s; logs are only available while the job runs", args[0], state)
+	}
+	if err != nil {
+		return err
+	}
+	jobID := job.Metadata.ID
+
+	fetch := func(ctx context.Context, offset int64) (*api.LogChunk, error) {
+		return client.GetJobManagerLogFromContext(ctx, ns, jobID, offset)
+	}
+	if taskManager != "" {
+		taskManagers, err := client.ListTaskManagersContext(ctx, ns, jobID)
+		if err != nil {
+			return fmt.Errorf("failed to list TaskManagers: %w", err)
+		}
+		if taskManager == "list" {
+			for _, tm := range taskManagers {
+				fmt.Println(tm.ID)
+			}
+			return nil
+		}
+		tmID, err := matchTaskManager(taskManager, taskManagers)
+		if err != nil {
+			return err
+		}
+		fetch = func(ctx context.Context, offset int64) (*api.LogChunk, error) {
+			return client.GetTaskManagerLogFromContext(ctx, ns, jobID, tmID, offset)
+		}
+	}
+
+	chunk, err := fetch(ctx, 0)
+	if err != nil {
+		return fmt.Errorf("failed to fetch log: %w", err)
+	}
+	fmt.Print(tailLines(chunk.Content, tail))
+	if !follow {
+		return nil
+	}
+
+	// Later polls only request what was appended. log keeps the whole log
+	// for servers that ignore the range and answer with all of it.
+	log := chunk.Content
+	offset := int64(len(chunk.Content))
+	wait := interval
+	for {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			// Interrupting --follow is the normal way to stop it
+			return nil
+		case <-timer.C:
+		}
+
+		chunk, err := fetch(ctx, offset)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to fetch log: %w", err)
+		}
+		added := chunk.Content
+		switch {
+		case chunk.Offset > 0:
+			log = ""
+		case log != "" && strings.HasPrefix(chunk.Content, log):
+			added = chunk.Content[len(log):]
+			log = chunk.Content
+		default:
+			// The log was rotated or the job restarted: print it from the start
+			log = chunk.Content
+		}
+		fmt.Print(added)
+		offset = chunk.Offset + int64(len(chunk.Content))
+		wait = followBackoff(wait, interval, added != "")
+	}
+}
+
+// maxFollowBackoff bounds how far --follow backs off while a log is idle
+const maxFollowBackoff = 30 * time.Second
+
+// followBackoff returns the wait before the next poll of a followed log: the
+// interval after new output, doubling while the log is idle up to eight times
+// the interval (but no more than maxFollowBackoff)
+func followBackoff(wait, interval time.Duration, gotOutput bool) time.Duration {
+	if gotOutput {
+		return interval
+	}
+	limit := min(8*interval, maxFollowBackoff)
+	return max(min(2*wait, limit), interval)
+}
+
+// matchTaskManager resolves a TaskManager ID or unique ID prefix
+func matchTaskManager(id string, taskManagers []api.TaskManager) (string, error) {
+	var matches []string
+	for _, tm := range taskManagers {
+		if tm.ID == id {
+			return tm.ID, nil
+		}
+		if strings.HasPrefix(tm.ID, id) {
+			matches = append(matches, tm.ID)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		ids := make([]string, 0, len(taskManagers))
+		for _, tm := range taskManagers {
+			ids = append(ids, tm.ID)
+		}
+		return "", fmt.Errorf("TaskManager %q not found (available: %s)", id, strings.Join(ids, ", "))
+	default:
+		return "", fmt.Errorf("TaskManager %q is ambiguous (matches: %s)", id, strings.Join(matches, ", "))
+	}
+}
+
+// tailLines returns the last n lines of s, or all of s if n is negative
+func tailLines(s string, n int) string {
+	if n < 0 {
+		return s
+	}
+	if n == 0 {
+		return ""
+	}
+	end := len(s)
+	if strings.HasSuffix(s, "\n") {
+		end--
+	}
+	for i := end - 1; i >= 0; i-- {
+		if s[i] == '\n' {
+			n--
+			if n == 0 {
+				return s[i+1:]
+			}
+		}
+	}
+	return s
+}
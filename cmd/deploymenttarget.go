@@ -1,20 +1,20 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"text/tabwriter"
 
 	"mcolomerc/vvp2cli/pkg/api"
+	"mcolomerc/vvp2cli/pkg/manifest"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 var (
 	deploymentTargetNamespace string
 	deploymentTargetFile      string
+	deploymentTargetRecursive bool
 )
 
 // deploymentTargetCmd represents the deployment-target command
@@ -75,10 +75,13 @@ func init() {
 	// Flags for deployment target commands
 	deploymentTargetCmd.PersistentFlags().StringVarP(&deploymentTargetNamespace, "namespace", "n", "", "Namespace (defaults to config if not set)")
 
-	createDeploymentTargetCmd.Flags().StringVarP(&deploymentTargetFile, "file", "f", "", "Path to deployment target YAML/JSON file (required)")
+	addSelectorFlag(listDeploymentTargetsCmd)
+
+	createDeploymentTargetCmd.Flags().StringVarP(&deploymentTargetFile, "file", "f", "", "Path to deployment target YAML/JSON file, directory, or - for stdin (required)")
+	createDeploymentTargetCmd.Flags().BoolVarP(&deploymentTargetRecursive, "recursive", "R", false, "Process the directory used in -f recursively")
 	createDeploymentTargetCmd.MarkFlagRequired("file")
 
-	updateDeploymentTargetCmd.Flags().StringVarP(&deploymentTargetFile, "file", "f", "", "Path to deployment target YAML/JSON file (required)")
+	updateDeploymentTargetCmd.Flags().StringVarP(&deploymentTargetFile, "file", "f", "", "Path to deployment target YAML/JSON file, or - for stdin (required)")
 	updateDeploymentTargetCmd.MarkFlagRequired("file")
 }
 
@@ -92,13 +95,19 @@ func runListDeploymentTargets(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	selector, err := labelSelector(cmd)
+	if err != nil {
+		return err
+	}
 
-	targets, err := client.ListDeploymentTargets(ns)
+	targets, err := client.ListDeploymentTargetsContext(cmd.Context(), ns)
 	if err != nil {
 		return fmt.Errorf("failed to list deployment targets: %w", err)
 	}
 
-	return printDeploymentTargets(targets.Items)
+	return printDeploymentTargets(filterByLabels(targets.Items, selector, func(t api.DeploymentTargetResource) map[string]string {
+		return t.Metadata.Labels
+	}))
 }
 
 func runGetDeploymentTarget(cmd *cobra.Command, args []string) error {
@@ -112,7 +121,7 @@ func runGetDeploymentTarget(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	target, err := client.GetDeploymentTarget(ns, args[0])
+	target, err := client.GetDeploymentTargetContext(cmd.Context(), ns, args[0])
 	if err != nil {
 		return fmt.Errorf("failed to get deployment target: %w", err)
 	}
@@ -131,18 +140,23 @@ func runCreateDeploymentTarget(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	target, err := loadDeploymentTargetFromFile(deploymentTargetFile)
+	targets, err := loadDeploymentTargetsFromFile(deploymentTargetFile, deploymentTargetRecursive)
 	if err != nil {
 		return err
 	}
 
-	result, err := client.CreateDeploymentTarget(ns, target)
-	if err != nil {
-		return fmt.Errorf("failed to create deployment target: %w", err)
-	}
+	for _, target := range targets {
+		result, err := client.CreateDeploymentTargetContext(cmd.Context(), ns, target)
+		if err != nil {
+			return fmt.Errorf("failed to create deployment target %s: %w", target.Metadata.Name, err)
+		}
 
-	fmt.Printf("Deployment target %s created successfully\n", result.Metadata.Name)
-	return printDeploymentTarget(result)
+		fmt.Printf("Deployment target %s created successfully\n", result.Metadata.Name)
+		if err := printDeploymentTarget(result); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func runUpdateDeploymentTarget(cmd *cobra.Command, args []string) error {
@@ -161,7 +175,7 @@ func runUpdateDeploymentTarget(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	result, err := client.UpdateDeploymentTarget(ns, args[0], target)
+	result, err := client.UpdateDeploymentTargetContext(cmd.Context(), ns, args[0], target)
 	if err != nil {
 		return fmt.Errorf("failed to update deployment target: %w", err)
 	}
@@ -181,7 +195,7 @@ func runDeleteDeploymentTarget(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if err := client.DeleteDeploymentTarget(ns, args[0]); err != nil {
+	if err := client.DeleteDeploymentTargetContext(cmd.Context(), ns, args[0]); err != nil {
 		return fmt.Errorf("failed to delete deployment target: %w", err)
 	}
 
@@ -190,42 +204,42 @@ func runDeleteDeploymentTarget(cmd *cobra.Command, args []string) error {
 }
 
 func loadDeploymentTargetFromFile(filename string) (*api.DeploymentTargetResource, error) {
-	data, err := os.ReadFile(filename)
+	obj, err := loadManifestObject(filename, manifest.KindDeploymentTarget)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+		return nil, err
 	}
 
 	var target api.DeploymentTargetResource
+	if err := obj.Into(&target); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
 
-	// Try JSON first
-	if err := json.Unmarshal(data, &target); err != nil {
-		// Try YAML
-		if err := yaml.Unmarshal(data, &target); err != nil {
-			return nil, fmt.Errorf("failed to parse file as JSON or YAML: %w", err)
-		}
+func loadDeploymentTargetsFromFile(filename string, recursive bool) ([]*api.DeploymentTargetResource, error) {
+	objs, err := loadManifestObjects(filename, manifest.KindDeploymentTarget, recursive)
+	if err != nil {
+		return nil, err
 	}
 
-	return &target, nil
+	targets := make([]*api.DeploymentTargetResource, 0, len(objs))
+	for _, obj := range objs {
+		var target api.DeploymentTargetResource
+		if err := obj.Into(&target); err != nil {
+			return nil, err
+		}
+		targets = append(targets, &target)
+	}
+	return targets, nil
 }
 
 func printDeploymentTargets(targets []api.DeploymentTargetResource) error {
-	if len(targets) == 0 {
-		fmt.Println("No deployment targets found")
-		return nil
-	}
+	return printOutput(manifest.KindDeploymentTarget, targets, func() error {
+		if len(targets) == 0 {
+			fmt.Println("No deployment targets found")
+			return nil
+		}
 
-	format := GetConfig().GetOutputFormat()
-
-	switch format {
-	case "json":
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(targets)
-	case "yaml":
-		encoder := yaml.NewEncoder(os.Stdout)
-		encoder.SetIndent(2)
-		return encoder.Encode(targets)
-	default:
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
 		fmt.Fprintln(w, "NAME\tNAMESPACE\tSTATE\tCREATED")
 		for _, t := range targets {
@@ -240,26 +254,13 @@ func printDeploymentTargets(targets []api.DeploymentTargetResource) error {
 				t.Metadata.CreatedAt.Format("2006-01-02 15:04:05"))
 		}
 		return w.Flush()
-	}
+	})
 }
 
 func printDeploymentTarget(target *api.DeploymentTargetResource) error {
-	format := GetConfig().GetOutputFormat()
-
-	switch format {
-	case "json":
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(target)
-	case "yaml":
-		encoder := yaml.NewEncoder(os.Stdout)
-		encoder.SetIndent(2)
-		return encoder.Encode(target)
-	default:
-		encoder := yaml.NewEncoder(os.Stdout)
-		encoder.SetIndent(2)
-		return encoder.Encode(target)
-	}
+	return printOutput(manifest.KindDeploymentTarget, target, func() error {
+		return printYAML(target)
+	})
 }
 
 // effectiveDeploymentTargetNamespace returns the namespace for deployment-target commands,
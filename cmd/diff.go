@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"mcolomerc/vvp2cli/pkg/api"
+	"mcolomerc/vvp2cli/pkg/diff"
+	"mcolomerc/vvp2cli/pkg/manifest"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// diffCmd shows what apply would change
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Diff local manifests against the live resources",
+	Long: `Show a unified diff between local manifests and the live resources.
+
+Only fields present in the manifest are compared, and server-managed fields
+(id, createdAt, modifiedAt, resourceVersion, status) are ignored. Resources
+that do not exist yet are shown as entirely new.
+
+Exit status is 0 when there are no differences, 1 when there are differences,
+and 2 when the diff could not be computed.`,
+	Example: `  vvp2 diff -f examples/mydeployment.yaml
+  vvp2 diff -f manifests/ -R --no-color`,
+	RunE: runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().StringP("file", "f", "", "Path to manifest YAML/JSON file, directory, or - for stdin (required)")
+	diffCmd.Flags().BoolP("recursive", "R", false, "Process the directory used in -f recursively")
+	diffCmd.Flags().StringP("namespace", "n", "", "Namespace for namespaced resources without metadata.namespace")
+	diffCmd.Flags().Bool("no-color", false, "Disable colored output")
+	diffCmd.MarkFlagRequired("file")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	filename, _ := cmd.Flags().GetString("file")
+	recursive, _ := cmd.Flags().GetBool("recursive")
+	flagNamespace, _ := cmd.Flags().GetString("namespace")
+	noColor, _ := cmd.Flags().GetBool("no-color")
+
+	// Differences are reported through the exit status, not as an error
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	objs, err := manifest.Read(filename, recursive)
+	if err != nil {
+		return &exitError{code: 2, err: err}
+	}
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return &exitError{code: 2, err: fmt.Errorf("failed to create API client: %w", err)}
+	}
+
+	color := !noColor && os.Getenv("NO_COLOR") == "" && isTerminal(os.Stdout)
+	changed := false
+	for _, obj := range objs {
+		out, err := diffObject(cmd.Context(), client, obj, flagNamespace)
+		if err != nil {
+			return &exitError{code: 2, err: fmt.Errorf("failed to diff %s: %w", obj, err)}
+		}
+		if out == "" {
+			continue
+		}
+		changed = true
+		if color {
+			out = diff.Colorize(out)
+		}
+		fmt.Print(out)
+	}
+
+	if changed {
+		return &exitError{code: 1}
+	}
+	return nil
+}
+
+// diffObject returns the unified diff between the live resource and the
+// manifest object, or an empty string when they match.
+func diffObject(ctx context.Context, client *api.Client, obj *manifest.Object, flagNamespace string) (string, error) {
+	rk, err := lookupResourceKind(obj)
+	if err != nil {
+		return "", err
+	}
+
+	ns := ""
+	if rk.namespaced {
+		if ns, err = manifestNamespace(obj, flagNamespace); err != nil {
+			return "", err
+		}
+	}
+
+	desired := rk.newObject()
+	if err := obj.Into(desired); err != nil {
+		return "", err
+	}
+
+	var live interface{}
+	if current, err := rk.get(ctx, client, ns, obj.Name); err == nil {
+		live = current
+	} else if !api.IsNotFound(err) {
+		return "", err
+	}
+
+	want, got, err := manifest.Comparable(obj, desired, live)
+	if err != nil {
+		return "", err
+	}
+
+	wantYAML, err := diffYAML(want)
+	if err != nil {
+		return "", err
+	}
+	gotYAML := ""
+	if got != nil {
+		if gotYAML, err = diffYAML(got); err != nil {
+			return "", err
+		}
+	}
+
+	return diff.Unified(gotYAML, wantYAML, "live/"+obj.String(), "local/"+obj.String(), diff.DefaultContext), nil
+}
+
+// diffYAML renders an object with the same indentation as -o yaml
+func diffYAML(v interface{}) (string, error) {
+	var sb strings.Builder
+	encoder := yaml.NewEncoder(&sb)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// isTerminal reports whether f is attached to a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -1,15 +1,16 @@
 package cmd
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"mcolomerc/vvp2cli/pkg/api"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 var jobCmd = &cobra.Command{
@@ -33,15 +34,32 @@ var jobGetCmd = &cobra.Command{
 	RunE:  runJobGet,
 }
 
+var jobInspectCmd = &cobra.Command{
+	Use:   "inspect [jobId]",
+	Short: "Show Flink runtime details of a running job",
+	Long: `Query the Flink REST API of a running job through the VVP Flink UI proxy and
+show its latest checkpoints, the most recent exception and, per vertex, the
+parallelism, backpressure and records in/out.
+
+The table output shows the first line of the exception; use -o yaml or
+-o json for the full stack trace.`,
+	Example: `  vvp2 job inspect 5a3f2c1e-... -n my-namespace
+  vvp2 job inspect 5a3f2c1e-... -o yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runJobInspect,
+}
+
 func init() {
 	rootCmd.AddCommand(jobCmd)
 
 	jobCmd.AddCommand(jobListCmd)
 	jobCmd.AddCommand(jobGetCmd)
+	jobCmd.AddCommand(jobInspectCmd)
 
 	// Add flags
 	jobListCmd.Flags().StringP("namespace", "n", "", "Namespace")
 	jobGetCmd.Flags().StringP("namespace", "n", "", "Namespace")
+	jobInspectCmd.Flags().StringP("namespace", "n", "", "Namespace")
 }
 
 func runJobList(cmd *cobra.Command, args []string) error {
@@ -58,7 +76,7 @@ func runJobList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	jobs, err := client.ListJobs(namespace)
+	jobs, err := client.ListJobsContext(cmd.Context(), namespace)
 	if err != nil {
 		return err
 	}
@@ -81,7 +99,7 @@ func runJobGet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	job, err := client.GetJob(namespace, jobID)
+	job, err := client.GetJobContext(cmd.Context(), namespace, jobID)
 	if err != nil {
 		return err
 	}
@@ -89,24 +107,35 @@ func runJobGet(cmd *cobra.Command, args []string) error {
 	return printJob(job)
 }
 
+func runJobInspect(cmd *cobra.Command, args []string) error {
+	jobID := args[0]
+	namespace, _ := cmd.Flags().GetString("namespace")
+	if namespace == "" {
+		namespace = cfg.Default.Namespace
+	}
+	if namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	inspection, err := client.InspectJobContext(cmd.Context(), namespace, jobID)
+	if errors.Is(err, api.ErrJobNotRunning) {
+		return fmt.Errorf("job %s has no Flink job to inspect: %w", jobID, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	return printJobInspection(inspection)
+}
+
 // Helper functions for printing jobs
 func printJobs(jobs []api.Job) error {
-	outputFormat, _ := rootCmd.PersistentFlags().GetString("output")
-
-	switch outputFormat {
-	case "json":
-		data, err := json.MarshalIndent(jobs, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-	case "yaml":
-		data, err := yaml.Marshal(jobs)
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-	default:
+	return printOutput("Job", jobs, func() error {
 		// Table format
 		if len(jobs) == 0 {
 			fmt.Println("No jobs found")
@@ -138,28 +167,12 @@ func printJobs(jobs []api.Job) error {
 				startTime,
 			)
 		}
-		w.Flush()
-	}
-	return nil
+		return w.Flush()
+	})
 }
 
 func printJob(job *api.Job) error {
-	outputFormat, _ := rootCmd.PersistentFlags().GetString("output")
-
-	switch outputFormat {
-	case "json":
-		data, err := json.MarshalIndent(job, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-	case "yaml":
-		data, err := yaml.Marshal(job)
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-	default:
+	return printOutput("Job", job, func() error {
 		// Table format with details
 		fmt.Printf("Job ID: %s\n", job.Metadata.ID)
 		if job.Metadata.Name != "" {
@@ -227,6 +240,80 @@ func printJob(job *api.Job) error {
 		if !job.Metadata.ModifiedAt.IsZero() {
 			fmt.Printf("Modified At: %s\n", job.Metadata.ModifiedAt.Format("2006-01-02 15:04:05"))
 		}
-	}
-	return nil
+		return nil
+	})
+}
+
+func printJobInspection(inspection *api.JobInspection) error {
+	return printOutput("Job", inspection, func() error {
+		fmt.Printf("Job ID: %s\n", inspection.JobID)
+		fmt.Printf("Flink Job ID: %s\n", inspection.FlinkJobID)
+		if inspection.Name != "" {
+			fmt.Printf("Name: %s\n", inspection.Name)
+		}
+		fmt.Printf("State: %s\n", inspection.State)
+		if !inspection.StartTime.IsZero() {
+			fmt.Printf("Start Time: %s\n", inspection.StartTime.Local().Format("2006-01-02 15:04:05"))
+		}
+
+		fmt.Println("\nCheckpoints:")
+		if cp := inspection.Checkpoints; cp == nil {
+			fmt.Println("  Checkpointing is not enabled")
+		} else {
+			fmt.Printf("  Completed: %d, Failed: %d, In Progress: %d\n", cp.Completed, cp.Failed, cp.InProgress)
+			if latest := cp.LatestCompleted; latest != nil {
+				kind := "checkpoint"
+				if latest.Savepoint {
+					kind = "savepoint"
+				}
+				fmt.Printf("  Latest Completed: #%d (%s) at %s, size %s, duration %s\n",
+					latest.ID,
+					kind,
+					latest.Time.Local().Format("2006-01-02 15:04:05"),
+					formatBytes(latest.Size),
+					time.Duration(latest.DurationMs)*time.Millisecond,
+				)
+			}
+			if failed := cp.LatestFailed; failed != nil {
+				fmt.Printf("  Latest Failed: #%d at %s", failed.ID, failed.Time.Local().Format("2006-01-02 15:04:05"))
+				if failed.Message != "" {
+					fmt.Printf(": %s", failed.Message)
+				}
+				fmt.Println()
+			}
+		}
+
+		fmt.Println("\nRoot Exception:")
+		if ex := inspection.RootException; ex == nil {
+			fmt.Println("  None")
+		} else {
+			if !ex.Time.IsZero() {
+				fmt.Printf("  Time: %s\n", ex.Time.Local().Format("2006-01-02 15:04:05"))
+			}
+			if ex.TaskName != "" {
+				fmt.Printf("  Task: %s\n", ex.TaskName)
+			}
+			firstLine, _, _ := strings.Cut(ex.StackTrace, "\n")
+			fmt.Printf("  Exception: %s\n", strings.TrimSpace(firstLine))
+		}
+
+		fmt.Println("\nVertices:")
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "  NAME\tSTATUS\tPARALLELISM\tBACKPRESSURE\tRECORDS IN\tRECORDS OUT")
+		for _, v := range inspection.Vertices {
+			backpressure := v.Backpressure
+			if backpressure == "" {
+				backpressure = "-"
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%d\t%s\t%d\t%d\n",
+				v.Name,
+				v.Status,
+				v.Parallelism,
+				backpressure,
+				v.RecordsIn,
+				v.RecordsOut,
+			)
+		}
+		return w.Flush()
+	})
 }
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"mcolomerc/vvp2cli/pkg/api"
+	"mcolomerc/vvp2cli/pkg/manifest"
+)
+
+// resourceKind describes how manifest-driven commands fetch, create and
+// update a single VVP resource kind.
+type resourceKind struct {
+	namespaced bool
+	newObject  func() interface{}
+	get        func(ctx context.Context, client *api.Client, namespace, name string) (interface{}, error)
+	create     func(ctx context.Context, client *api.Client, namespace string, obj interface{}) error
+	update     func(ctx context.Context, client *api.Client, namespace, name string, obj interface{}) error
+}
+
+// resourceKinds maps manifest kinds to their API operations
+var resourceKinds = map[string]resourceKind{
+	manifest.KindNamespace: {
+		newObject: func() interface{} { return &api.Namespace{} },
+		get: func(ctx context.Context, client *api.Client, _, name string) (interface{}, error) {
+			return client.GetNamespaceContext(ctx, name)
+		},
+		create: func(ctx context.Context, client *api.Client, _ string, obj interface{}) error {
+			_, err := client.CreateNamespaceContext(ctx, obj.(*api.Namespace))
+			return err
+		},
+		update: func(ctx context.Context, client *api.Client, _, name string, obj interface{}) error {
+			_, err := client.UpdateNamespaceContext(ctx, name, obj.(*api.Namespace))
+			return err
+		},
+	},
+	manifest.KindDeploymentTarget: {
+		namespaced: true,
+		newObject:  func() interface{} { return &api.DeploymentTargetResource{} },
+		get: func(ctx context.Context, client *api.Client, namespace, name string) (interface{}, error) {
+			return client.GetDeploymentTargetContext(ctx, namespace, name)
+		},
+		create: func(ctx context.Context, client *api.Client, namespace string, obj interface{}) error {
+			_, err := client.CreateDeploymentTargetContext(ctx, namespace, obj.(*api.DeploymentTargetResource))
+			return err
+		},
+		update: func(ctx context.Context, client *api.Client, namespace, name string, obj interface{}) error {
+			_, err := client.UpdateDeploymentTargetContext(ctx, namespace, name, obj.(*api.DeploymentTargetResource))
+			return err
+		},
+	},
+	manifest.KindSecretValue: {
+		namespaced: true,
+		newObject:  func() interface{} { return &api.SecretValue{} },
+		get: func(ctx context.Context, client *api.Client, namespace, name string) (interface{}, error) {
+			return client.GetSecretValueContext(ctx, namespace, name)
+		},
+		create: func(ctx context.Context, client *api.Client, namespace string, obj interface{}) error {
+			_, err := client.CreateSecretValueContext(ctx, namespace, obj.(*api.SecretValue))
+			return err
+		},
+		update: func(ctx context.Context, client *api.Client, namespace, name string, obj interface{}) error {
+			_, err := client.UpdateSecretValueContext(ctx, namespace, name, obj.(*api.SecretValue))
+			return err
+		},
+	},
+	manifest.KindSessionCluster: {
+		namespaced: true,
+		newObject:  func() interface{} { return &api.SessionCluster{} },
+		get: func(ctx context.Context, client *api.Client, namespace, name string) (interface{}, error) {
+			return client.GetSessionClusterContext(ctx, namespace, name)
+		},
+		create: func(ctx context.Context, client *api.Client, namespace string, obj interface{}) error {
+			_, err := client.CreateSessionClusterContext(ctx, namespace, obj.(*api.SessionCluster))
+			return err
+		},
+		update: func(ctx context.Context, client *api.Client, namespace, name string, obj interface{}) error {
+			_, err := client.UpsertSessionClusterContext(ctx, namespace, name, obj.(*api.SessionCluster))
+			return err
+		},
+	},
+	manifest.KindDeployment: {
+		namespaced: true,
+		newObject:  func() interface{} { return &api.Deployment{} },
+		get: func(ctx context.Context, client *api.Client, namespace, name string) (interface{}, error) {
+			return client.GetDeploymentContext(ctx, namespace, name)
+		},
+		create: func(ctx context.Context, client *api.Client, namespace string, obj interface{}) error {
+			_, err := client.CreateDeploymentContext(ctx, namespace, obj.(*api.Deployment))
+			return err
+		},
+		update: func(ctx context.Context, client *api.Client, namespace, name string, obj interface{}) error {
+			_, err := client.UpdateDeploymentContext(ctx, namespace, name, obj.(*api.Deployment))
+			return err
+		},
+	},
+	manifest.KindDeploymentDefaults: {
+		namespaced: true,
+		newObject:  func() interface{} { return &api.DeploymentDefaults{} },
+		get: func(ctx context.Context, client *api.Client, namespace, _ string) (interface{}, error) {
+			return client.GetDeploymentDefaultsContext(ctx, namespace)
+		},
+		// Deployment defaults always exist; creating them means replacing them
+		create: func(ctx context.Context, client *api.Client, namespace string, obj interface{}) error {
+			_, err := client.ReplaceDeploymentDefaultsContext(ctx, namespace, obj.(*api.DeploymentDefaults))
+			return err
+		},
+		update: func(ctx context.Context, client *api.Client, namespace, _ string, obj interface{}) error {
+			_, err := client.ReplaceDeploymentDefaultsContext(ctx, namespace, obj.(*api.DeploymentDefaults))
+			return err
+		},
+	},
+}
+
+// lookupResourceKind returns the operations for a manifest kind
+func lookupResourceKind(obj *manifest.Object) (resourceKind, error) {
+	if obj.Kind == "" {
+		return resourceKind{}, fmt.Errorf("%s: manifest has no kind", obj.Source)
+	}
+	rk, ok := resourceKinds[obj.Kind]
+	if !ok {
+		return resourceKind{}, fmt.Errorf("%s: unsupported kind %q (supported: %s)", obj.Source, obj.Kind, strings.Join(supportedKinds(), ", "))
+	}
+	if obj.Name == "" && obj.Kind != manifest.KindDeploymentDefaults {
+		return resourceKind{}, fmt.Errorf("%s: %s manifest has no metadata.name", obj.Source, obj.Kind)
+	}
+	return rk, nil
+}
+
+// manifestNamespace resolves the namespace for a namespaced manifest object,
+// preferring metadata.namespace, then the --namespace flag, then the config default.
+func manifestNamespace(obj *manifest.Object, flagNamespace string) (string, error) {
+	if obj.Namespace != "" {
+		if flagNamespace != "" && flagNamespace != obj.Namespace {
+			return "", fmt.Errorf("%s: namespace %q in manifest does not match --namespace %q", obj.Source, obj.Namespace, flagNamespace)
+		}
+		return obj.Namespace, nil
+	}
+	if flagNamespace != "" {
+		return flagNamespace, nil
+	}
+	if ns := GetConfig().GetNamespace(); ns != "" {
+		return ns, nil
+	}
+	return "", fmt.Errorf("namespace not specified. Provide --namespace, set metadata.namespace, or set default.namespace in ~/.vvp2/config.yaml (or VVP_DEFAULT_NAMESPACE)")
+}
+
+func supportedKinds() []string {
+	return []string{
+		manifest.KindNamespace,
+		manifest.KindDeploymentTarget,
+		manifest.KindSecretValue,
+		manifest.KindSessionCluster,
+		manifest.KindDeployment,
+		manifest.KindDeploymentDefaults,
+	}
+}
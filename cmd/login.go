@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+
+	"mcolomerc/vvp2cli/pkg/api"
+	"mcolomerc/vvp2cli/pkg/auth"
+
+	"github.com/spf13/cobra"
+)
+
+// loginCmd logs in to the OIDC provider in front of the API
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in to the OIDC provider protecting the API",
+	Long: `Log in with OpenID Connect and store the tokens for the current context.
+
+By default the OAuth2 device authorization flow is used: vvp2 prints a URL and
+a code to enter in a browser. With --client-credentials, the client ID and
+secret from the configuration are exchanged for a token, which suits CI.
+
+The provider is configured in the api.oidc section (per context if needed):
+
+  api:
+    oidc:
+      issuer: https://login.example.com/realms/vvp
+      clientId: vvp2-cli
+      scopes: [openid, offline_access]
+
+Access tokens are refreshed automatically before API requests.`,
+	Example: `  vvp2 login
+  vvp2 login --client-credentials
+  vvp2 --context prod login`,
+	RunE: runLogin,
+}
+
+// logoutCmd removes stored OIDC tokens
+var logoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove the stored login for the current context",
+	RunE:  runLogout,
+}
+
+func init() {
+	rootCmd.AddCommand(loginCmd)
+	rootCmd.AddCommand(logoutCmd)
+
+	loginCmd.Flags().Bool("client-credentials", false, "Use the client credentials flow instead of the device flow")
+}
+
+func runLogin(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if cfg == nil {
+		return fmt.Errorf("configuration could not be loaded")
+	}
+	if cfg.API.OIDC == nil || cfg.API.OIDC.ClientID == "" {
+		return fmt.Errorf("OIDC is not configured: set api.oidc.issuer and api.oidc.clientId (see 'vvp2 login --help')")
+	}
+
+	// Reach the provider with the TLS and proxy settings of the API
+	httpClient, err := api.NewHTTPClient(cfg)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	client := auth.NewOIDCClient(cfg.API.OIDC, httpClient)
+
+	var token *auth.Token
+	if useClientCredentials, _ := cmd.Flags().GetBool("client-credentials"); useClientCredentials {
+		token, err = client.ClientCredentials(ctx)
+	} else {
+		var device *auth.DeviceAuthorization
+		device, err = client.StartDeviceAuthorization(ctx)
+		if err != nil {
+			return err
+		}
+
+		if device.VerificationURIComplete != "" {
+			fmt.Printf("To log in, open the following URL in a browser:\n\n  %s\n\n", device.VerificationURIComplete)
+			fmt.Printf("and confirm the code %s\n\n", device.UserCode)
+		} else {
+			fmt.Printf("To log in, open %s in a browser and enter the code:\n\n  %s\n\n", device.VerificationURI, device.UserCode)
+		}
+		fmt.Println("Waiting for authorization...")
+		token, err = client.PollDeviceToken(ctx, device)
+	}
+	if err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+
+	store, err := auth.DefaultTokenStore()
+	if err != nil {
+		return err
+	}
+	cred := &auth.StoredCredential{Token: *token, TokenEndpoint: client.TokenEndpoint}
+	if err := store.Save(cfg.ActiveContext, cred); err != nil {
+		return err
+	}
+
+	fmt.Printf("Logged in%s\n", contextSuffix(cfg.ActiveContext))
+	if !token.Expiry.IsZero() {
+		fmt.Printf("Access token expires at %s", token.Expiry.Local().Format("2006-01-02 15:04:05"))
+		if token.RefreshToken != "" {
+			fmt.Print(" and will be refreshed automatically")
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+func runLogout(cmd *cobra.Command, args []string) error {
+	activeContext := ""
+	if cfg := GetConfig(); cfg != nil {
+		activeContext = cfg.ActiveContext
+	}
+
+	store, err := auth.DefaultTokenStore()
+	if err != nil {
+		return err
+	}
+	removed, err := store.Delete(activeContext)
+	if err != nil {
+		return err
+	}
+
+	if !removed {
+		fmt.Printf("Not logged in%s\n", contextSuffix(activeContext))
+		return nil
+	}
+	fmt.Printf("Logged out%s\n", contextSuffix(activeContext))
+	return nil
+}
+
+// contextSuffix describes the active context for status messages
+func contextSuffix(name string) string {
+	if name == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (context %q)", name)
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+
+	"mcolomerc/vvp2cli/pkg/manifest"
+)
+
+// loadManifestObjects reads every object of the given kind from a file,
+// directory or stdin ("-"). Documents without a kind are assumed to be of
+// the expected kind; documents of any other kind are rejected.
+func loadManifestObjects(filename, kind string, recursive bool) ([]*manifest.Object, error) {
+	objs, err := manifest.Read(filename, recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range objs {
+		if obj.Kind == "" {
+			obj.Kind = kind
+		}
+		if obj.Kind != kind {
+			return nil, fmt.Errorf("%s: expected kind %s, got %s (use 'vvp2 apply' for mixed manifests)", obj.Source, kind, obj.Kind)
+		}
+	}
+	return objs, nil
+}
+
+// loadManifestObject reads exactly one object of the given kind
+func loadManifestObject(filename, kind string) (*manifest.Object, error) {
+	objs, err := loadManifestObjects(filename, kind, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(objs) != 1 {
+		return nil, fmt.Errorf("%s: expected a single %s, found %d objects", filename, kind, len(objs))
+	}
+	return objs[0], nil
+}
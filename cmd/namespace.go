@@ -1,19 +1,19 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"text/tabwriter"
 
 	"mcolomerc/vvp2cli/pkg/api"
+	"mcolomerc/vvp2cli/pkg/manifest"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 var (
-	namespaceFile string
+	namespaceFile      string
+	namespaceRecursive bool
 )
 
 // namespaceCmd represents the namespace command
@@ -72,10 +72,11 @@ func init() {
 	namespaceCmd.AddCommand(deleteNamespaceCmd)
 
 	// Flags for namespace commands
-	createNamespaceCmd.Flags().StringVarP(&namespaceFile, "file", "f", "", "Path to namespace YAML/JSON file (required)")
+	createNamespaceCmd.Flags().StringVarP(&namespaceFile, "file", "f", "", "Path to namespace YAML/JSON file, directory, or - for stdin (required)")
+	createNamespaceCmd.Flags().BoolVarP(&namespaceRecursive, "recursive", "R", false, "Process the directory used in -f recursively")
 	createNamespaceCmd.MarkFlagRequired("file")
 
-	updateNamespaceCmd.Flags().StringVarP(&namespaceFile, "file", "f", "", "Path to namespace YAML/JSON file (required)")
+	updateNamespaceCmd.Flags().StringVarP(&namespaceFile, "file", "f", "", "Path to namespace YAML/JSON file, or - for stdin (required)")
 	updateNamespaceCmd.MarkFlagRequired("file")
 }
 
@@ -85,7 +86,7 @@ func runListNamespaces(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	namespaces, err := client.ListNamespaces()
+	namespaces, err := client.ListNamespacesContext(cmd.Context())
 	if err != nil {
 		return fmt.Errorf("failed to list namespaces: %w", err)
 	}
@@ -99,7 +100,7 @@ func runGetNamespace(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	namespace, err := client.GetNamespace(args[0])
+	namespace, err := client.GetNamespaceContext(cmd.Context(), args[0])
 	if err != nil {
 		return fmt.Errorf("failed to get namespace: %w", err)
 	}
@@ -113,18 +114,23 @@ func runCreateNamespace(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	namespace, err := loadNamespaceFromFile(namespaceFile)
+	namespaces, err := loadNamespacesFromFile(namespaceFile, namespaceRecursive)
 	if err != nil {
 		return err
 	}
 
-	result, err := client.CreateNamespace(namespace)
-	if err != nil {
-		return fmt.Errorf("failed to create namespace: %w", err)
-	}
+	for _, namespace := range namespaces {
+		result, err := client.CreateNamespaceContext(cmd.Context(), namespace)
+		if err != nil {
+			return fmt.Errorf("failed to create namespace %s: %w", namespace.Metadata.Name, err)
+		}
 
-	fmt.Printf("Namespace %s created successfully\n", result.Metadata.Name)
-	return printNamespace(result)
+		fmt.Printf("Namespace %s created successfully\n", result.Metadata.Name)
+		if err := printNamespace(result); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func runUpdateNamespace(cmd *cobra.Command, args []string) error {
@@ -138,7 +144,7 @@ func runUpdateNamespace(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	result, err := client.UpdateNamespace(args[0], namespace)
+	result, err := client.UpdateNamespaceContext(cmd.Context(), args[0], namespace)
 	if err != nil {
 		return fmt.Errorf("failed to update namespace: %w", err)
 	}
@@ -153,7 +159,7 @@ func runDeleteNamespace(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	if err := client.DeleteNamespace(args[0]); err != nil {
+	if err := client.DeleteNamespaceContext(cmd.Context(), args[0]); err != nil {
 		return fmt.Errorf("failed to delete namespace: %w", err)
 	}
 
@@ -162,42 +168,42 @@ func runDeleteNamespace(cmd *cobra.Command, args []string) error {
 }
 
 func loadNamespaceFromFile(filename string) (*api.Namespace, error) {
-	data, err := os.ReadFile(filename)
+	obj, err := loadManifestObject(filename, manifest.KindNamespace)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+		return nil, err
 	}
 
 	var namespace api.Namespace
+	if err := obj.Into(&namespace); err != nil {
+		return nil, err
+	}
+	return &namespace, nil
+}
 
-	// Try JSON first
-	if err := json.Unmarshal(data, &namespace); err != nil {
-		// Try YAML
-		if err := yaml.Unmarshal(data, &namespace); err != nil {
-			return nil, fmt.Errorf("failed to parse file as JSON or YAML: %w", err)
-		}
+func loadNamespacesFromFile(filename string, recursive bool) ([]*api.Namespace, error) {
+	objs, err := loadManifestObjects(filename, manifest.KindNamespace, recursive)
+	if err != nil {
+		return nil, err
 	}
 
-	return &namespace, nil
+	namespaces := make([]*api.Namespace, 0, len(objs))
+	for _, obj := range objs {
+		var namespace api.Namespace
+		if err := obj.Into(&namespace); err != nil {
+			return nil, err
+		}
+		namespaces = append(namespaces, &namespace)
+	}
+	return namespaces, nil
 }
 
 func printNamespaces(namespaces []api.Namespace) error {
-	if len(namespaces) == 0 {
-		fmt.Println("No namespaces found")
-		return nil
-	}
-
-	format := GetConfig().GetOutputFormat()
-
-	switch format {
-	case "json":
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(namespaces)
-	case "yaml":
-		encoder := yaml.NewEncoder(os.Stdout)
-		encoder.SetIndent(2)
-		return encoder.Encode(namespaces)
-	default:
+	return printOutput(manifest.KindNamespace, namespaces, func() error {
+		if len(namespaces) == 0 {
+			fmt.Println("No namespaces found")
+			return nil
+		}
+
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
 		fmt.Fprintln(w, "NAME\tSTATE\tCREATED")
 		for _, ns := range namespaces {
@@ -211,24 +217,11 @@ func printNamespaces(namespaces []api.Namespace) error {
 				ns.Metadata.CreatedAt.Format("2006-01-02 15:04:05"))
 		}
 		return w.Flush()
-	}
+	})
 }
 
 func printNamespace(namespace *api.Namespace) error {
-	format := GetConfig().GetOutputFormat()
-
-	switch format {
-	case "json":
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(namespace)
-	case "yaml":
-		encoder := yaml.NewEncoder(os.Stdout)
-		encoder.SetIndent(2)
-		return encoder.Encode(namespace)
-	default:
-		encoder := yaml.NewEncoder(os.Stdout)
-		encoder.SetIndent(2)
-		return encoder.Encode(namespace)
-	}
+	return printOutput(manifest.KindNamespace, namespace, func() error {
+		return printYAML(namespace)
+	})
 }
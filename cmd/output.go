@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"os"
+
+	"mcolomerc/vvp2cli/pkg/printer"
+
+	"github.com/spf13/viper"
+)
+
+// outputFormat returns the -o format for the running command. Viper resolves
+// output.format with the precedence --output flag, VVP_OUTPUT_FORMAT,
+// output.format of the active context, top-level output.format, so every
+// command prints in the same format for the same invocation.
+func outputFormat() string {
+	if cfg != nil {
+		return cfg.GetOutputFormat()
+	}
+	// The configuration failed to load (e.g. no API URL); the format is
+	// still known to viper
+	return viper.GetString("output.format")
+}
+
+// printOutput prints v in the resolved output format. The table format is
+// specific to each resource and is printed by table; every other format is
+// rendered by the shared printer, with kind naming objects in -o name.
+func printOutput(kind string, v interface{}, table func() error) error {
+	f, err := printer.ParseFormat(outputFormat())
+	if err != nil {
+		return err
+	}
+	if f.IsTable() {
+		return table()
+	}
+	return printer.Print(os.Stdout, f, v, printer.Options{Kind: kind})
+}
+
+// isTableOutput reports whether the resolved format is the human-readable
+// one, for commands that print a summary instead of the resource in that case
+func isTableOutput() bool {
+	f, err := printer.ParseFormat(outputFormat())
+	return err == nil && f.IsTable()
+}
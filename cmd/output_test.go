@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"mcolomerc/vvp2cli/pkg/api"
+	"mcolomerc/vvp2cli/pkg/config"
+	"mcolomerc/vvp2cli/pkg/printer"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func TestMain(m *testing.M) {
+	// Tables print some times in local time
+	time.Local = time.UTC
+	os.Exit(m.Run())
+}
+
+// fixture decodes a JSON document into a value of type T
+func fixture[T any](t *testing.T, data string) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("Failed to decode fixture: %v", err)
+	}
+	return v
+}
+
+// captureStdout returns what fn prints to os.Stdout
+func captureStdout(t *testing.T, fn func() error) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	done := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- data
+	}()
+
+	err = fn()
+	os.Stdout = stdout
+	w.Close()
+	out := <-done
+	if err != nil {
+		t.Fatalf("Failed to print: %v", err)
+	}
+	return string(out)
+}
+
+// withOutputFormat makes format the resolved output format for the test
+func withOutputFormat(t *testing.T, format string) {
+	t.Helper()
+	saved := cfg
+	cfg = &config.Config{Output: config.OutputConfig{Format: format}}
+	t.Cleanup(func() { cfg = saved })
+}
+
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatalf("Failed to update %s: %v", path, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s (run go test ./cmd -update to create it): %v", path, err)
+	}
+	if got != string(want) {
+		t.Errorf("Output differs from %s:\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
+
+func TestOutputGolden(t *testing.T) {
+	kinds := map[string]func() error{
+		"deployments": func() error {
+			return printDeployments(fixture[[]api.Deployment](t, `[
+				{"metadata": {"name": "orders", "namespace": "default", "id": "d-1", "labels": {"team": "data"}, "createdAt": "2026-01-02T10:00:00Z"},
+				 "spec": {"state": "RUNNING", "deploymentTargetName": "vvp-jobs", "template": {"spec": {"artifact": {"kind": "JAR", "jarUri": "s3://jars/orders.jar"}, "parallelism": 2}}},
+				 "status": {"state": "RUNNING"}},
+				{"metadata": {"name": "payments", "namespace": "default", "id": "d-2", "createdAt": "2026-01-03T11:30:00Z"},
+				 "spec": {"state": "SUSPENDED", "template": {"spec": {"artifact": {"kind": "SQLSCRIPT", "sqlScript": "SELECT 1"}}}}}
+			]`))
+		},
+		"deploymenttargets": func() error {
+			return printDeploymentTargets(fixture[[]api.DeploymentTargetResource](t, `[
+				{"metadata": {"name": "vvp-jobs", "namespace": "default", "createdAt": "2026-01-01T08:00:00Z"},
+				 "spec": {"kubernetes": {"namespace": "vvp-jobs"}}}
+			]`))
+		},
+		"deploymentdefaults": func() error {
+			dd := fixture[api.DeploymentDefaults](t, `
+				{"metadata": {"name": "default", "namespace": "default"},
+				 "spec": {"template": {"spec": {"parallelism": 1}}}}`)
+			return printDeploymentDefaults(&dd)
+		},
+		"events": func() error {
+			return printEvents(fixture[[]api.Event](t, `[
+				{"metadata": {"id": "e-1", "jobId": "j-1"}, "spec": {"timestamp": "2026-01-02T10:00:05Z", "severity": "INFO", "message": "Job is starting"}},
+				{"metadata": {"id": "e-2"}, "spec": {"timestamp": "2026-01-02T10:01:00Z", "severity": "ERROR", "message": "Job failed:\n  caused by OOM"}}
+			]`))
+		},
+		"namespaces": func() error {
+			return printNamespaces(fixture[[]api.Namespace](t, `[
+				{"metadata": {"name": "default", "createdAt": "2025-12-01T00:00:00Z"}, "status": {"state": "READY"}},
+				{"metadata": {"name": "team-a", "createdAt": "2025-12-02T00:00:00Z"}}
+			]`))
+		},
+		"jobs": func() error {
+			return printJobs(fixture[[]api.Job](t, `[
+				{"metadata": {"id": "j-1", "namespace": "default"}, "spec": {"deploymentId": "d-1"},
+				 "status": {"state": "STARTED", "running": {"startTime": "2026-01-02T10:00:00Z", "jobId": "f-1"}}}
+			]`))
+		},
+		"savepoints": func() error {
+			return printSavepoints(fixture[[]api.Savepoint](t, `[
+				{"metadata": {"id": "sp-1", "namespace": "default", "createdAt": "2026-01-02T12:00:00Z"},
+				 "spec": {"deploymentId": "d-1", "jobId": "j-1", "savepointLocation": "s3://savepoints/sp-1"},
+				 "status": {"state": "COMPLETED"}}
+			]`))
+		},
+		"secretvalues": func() error {
+			return printSecretValues(fixture[[]api.SecretValue](t, `[
+				{"metadata": {"name": "db-password", "namespace": "default", "createdAt": "2026-01-01T09:00:00Z"}, "spec": {"kind": "GENERIC"}}
+			]`))
+		},
+		"sessions": func() error {
+			return printSessions(fixture[[]api.Session](t, `[
+				{"metadata": {"name": "sql-editor", "namespace": "default"},
+				 "spec": {"deploymentTargetId": "t-1", "flinkVersion": "1.19", "sessionClusterResourceProfile": {"cpu": "2", "memory": "4G"}},
+				 "status": {"state": "RUNNING"}}
+			]`))
+		},
+		"sessionclusters": func() error {
+			return printSessionClusters(fixture[[]api.SessionCluster](t, `[
+				{"metadata": {"name": "sql-session", "namespace": "default"},
+				 "spec": {"state": "RUNNING", "deploymentTargetName": "vvp-jobs", "flinkVersion": "1.19", "numberOfTaskManagers": 2},
+				 "status": {"state": "RUNNING"}},
+				{"metadata": {"name": "adhoc", "namespace": "default"},
+				 "spec": {"state": "STOPPED", "flinkVersion": "1.18", "numberOfTaskManagers": 1}}
+			]`))
+		},
+		"apitokens": func() error {
+			return printAPITokens(fixture[[]api.APIToken](t, `[
+				{"name": "namespaces/default/apitokens/ci", "role": "editor", "createTime": "2026-01-01T00:00:00Z"}
+			]`))
+		},
+		"artifacts": func() error {
+			return printArtifacts(fixture[[]api.ArtifactFile](t, `[
+				{"filename": "orders.jar", "uri": "s3://artifacts/namespaces/default/orders.jar", "size": 1572864, "createTime": "2026-01-02T09:00:00Z"}
+			]`))
+		},
+	}
+
+	for kind, render := range kinds {
+		for _, format := range []string{"table", "json", "yaml"} {
+			t.Run(kind+"/"+format, func(t *testing.T) {
+				withOutputFormat(t, format)
+				assertGolden(t, kind+"."+format, captureStdout(t, render))
+			})
+		}
+	}
+}
+
+func TestOutputFormatPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte(`api:
+  url: http://vvp.example.com
+output:
+  format: yaml
+contexts:
+  - name: prod
+    output:
+      format: json
+  - name: dev
+`), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	saved, savedFile := cfg, cfgFile
+	t.Cleanup(func() {
+		cfg, cfgFile = saved, savedFile
+		resetFlag(t, "output")
+		resetFlag(t, "context")
+	})
+	cfgFile = path
+
+	tests := []struct {
+		name    string
+		context string
+		env     string
+		flag    string
+		want    string
+	}{
+		{name: "config", want: "yaml"},
+		{name: "context without output", context: "dev", want: "yaml"},
+		{name: "context", context: "prod", want: "json"},
+		{name: "env", context: "prod", env: "name", want: "name"},
+		{name: "flag", context: "prod", env: "name", flag: "custom-columns=NAME:.metadata.name", want: "custom-columns=NAME:.metadata.name"},
+		{name: "explicit table flag", context: "prod", env: "name", flag: "table", want: "table"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlag(t, "output")
+			resetFlag(t, "context")
+			if tt.context != "" {
+				rootCmd.PersistentFlags().Set("context", tt.context)
+			}
+			if tt.flag != "" {
+				rootCmd.PersistentFlags().Set("output", tt.flag)
+			}
+			if tt.env != "" {
+				t.Setenv("VVP_OUTPUT_FORMAT", tt.env)
+			}
+
+			initConfig()
+			if got := outputFormat(); got != tt.want {
+				t.Errorf("Expected output format %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// resetFlag restores a root flag to its default, as if it was not given
+func resetFlag(t *testing.T, name string) {
+	t.Helper()
+	f := rootCmd.PersistentFlags().Lookup(name)
+	f.Value.Set(f.DefValue)
+	f.Changed = false
+}
+
+func TestConfigOutputFormatsMatchPrinter(t *testing.T) {
+	var names []string
+	for _, name := range config.OutputFormats {
+		names = append(names, name)
+		if _, err := printer.ParseFormat(name); err != nil {
+			t.Errorf("config format %s: %v", name, err)
+		}
+	}
+	for _, name := range config.TemplateOutputFormats {
+		names = append(names, name+"=...")
+		if _, err := printer.ParseFormat(name + "={.x}"); err != nil {
+			t.Errorf("config format %s: %v", name, err)
+		}
+	}
+	if strings.Join(names, ",") != strings.Join(printer.Formats, ",") {
+		t.Errorf("Expected config formats %v to match printer formats %v", names, printer.Formats)
+	}
+}
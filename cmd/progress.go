@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// progress reports the bytes written to it on stderr. Updates are redrawn in
+// place on a terminal and suppressed otherwise, so CI logs only get the
+// final summary.
+type progress struct {
+	label string
+	total int64 // 0 when the size is unknown
+	done  int64
+
+	out         io.Writer
+	interactive bool
+	lastDraw    time.Time
+}
+
+func newProgress(label string, total int64) *progress {
+	p := &progress{label: label, total: total, out: os.Stderr}
+	if info, err := os.Stderr.Stat(); err == nil {
+		p.interactive = info.Mode()&os.ModeCharDevice != 0
+	}
+	return p
+}
+
+// Write counts transferred bytes; it never fails
+func (p *progress) Write(b []byte) (int, error) {
+	p.done += int64(len(b))
+	if p.interactive && time.Since(p.lastDraw) >= 100*time.Millisecond {
+		p.draw()
+		p.lastDraw = time.Now()
+	}
+	return len(b), nil
+}
+
+// Finish draws the final state and ends the progress line
+func (p *progress) Finish() {
+	if p.interactive {
+		p.draw()
+		fmt.Fprintln(p.out)
+	}
+}
+
+func (p *progress) draw() {
+	if p.total > 0 {
+		done := min(p.done, p.total)
+		fmt.Fprintf(p.out, "\r%s: %3d%% (%s / %s)", p.label, done*100/p.total, formatBytes(done), formatBytes(p.total))
+		return
+	}
+	fmt.Fprintf(p.out, "\r%s: %s", p.label, formatBytes(p.done))
+}
+
+// formatBytes formats a size with binary units
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -1,10 +1,16 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"mcolomerc/vvp2cli/pkg/config"
+	"mcolomerc/vvp2cli/pkg/printer"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -31,34 +37,93 @@ var rootCmd = &cobra.Command{
 	Short: "A CLI tool to interact with Ververica Platform API",
 	Long: `vvp2 is a command-line interface tool for interacting with the Ververica Platform API.
 It provides commands to manage deployments, namespaces, session clusters, and other VVP resources.`,
+	// Errors are printed once by Execute; API failures should not dump usage
+	SilenceUsage:  true,
+	SilenceErrors: true,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// Commands run with a context that is cancelled on Ctrl-C or SIGTERM, which
+// aborts any in-flight API request.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	interrupted := ctx.Err() != nil
+	stop()
+
+	if err != nil {
+		if interrupted {
+			fmt.Fprintln(os.Stderr, "Error: interrupted")
+			os.Exit(130)
+		}
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			if exitErr.err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", exitErr.err)
+			}
+			os.Exit(exitErr.code)
+		}
+		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
 }
 
+// exitError makes the process exit with a specific status code. The wrapped
+// error, if any, is printed; a nil error exits silently.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	if e.err != nil {
+		return e.err.Error()
+	}
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.vvp2/config.yaml)")
+	rootCmd.PersistentFlags().String("context", "", "Name of the config context to use (overrides current-context)")
 	rootCmd.PersistentFlags().String("api-url", "", "Ververica Platform API URL")
 	rootCmd.PersistentFlags().String("api-token", "", "API authentication token")
+	rootCmd.PersistentFlags().String("api-token-file", "", "File to read the API token from on every invocation")
 	rootCmd.PersistentFlags().String("namespace", "", "Default namespace")
 	rootCmd.PersistentFlags().Bool("insecure", false, "Skip TLS certificate verification")
-	rootCmd.PersistentFlags().StringP("output", "o", "table", "Output format (table, json, yaml)")
+	rootCmd.PersistentFlags().String("ca-file", "", "PEM bundle of CA certificates to trust in addition to the system roots")
+	rootCmd.PersistentFlags().String("client-cert", "", "Client certificate (PEM) for mutual TLS")
+	rootCmd.PersistentFlags().String("client-key", "", "Client private key (PEM) for mutual TLS")
+	rootCmd.PersistentFlags().StringP("output", "o", "table", "Output format ("+strings.Join(printer.Formats, ", ")+")")
+	rootCmd.PersistentFlags().Int("retries", 3, "Retries for failed idempotent API requests (0 disables retries)")
+	rootCmd.PersistentFlags().Duration("retry-wait", config.DefaultRetryWaitTime, "Initial backoff between retries")
+	rootCmd.PersistentFlags().Duration("retry-max-wait", config.DefaultRetryMaxWaitTime, "Maximum backoff between retries")
+	rootCmd.PersistentFlags().Bool("retry-all-methods", false, "Also retry non-idempotent requests (POST, PATCH)")
 
 	// Bind flags to viper
+	viper.BindPFlag(config.ContextKey, rootCmd.PersistentFlags().Lookup("context"))
 	viper.BindPFlag("api.url", rootCmd.PersistentFlags().Lookup("api-url"))
 	viper.BindPFlag("api.token", rootCmd.PersistentFlags().Lookup("api-token"))
+	viper.BindPFlag("api.tokenFile", rootCmd.PersistentFlags().Lookup("api-token-file"))
 	viper.BindPFlag("api.insecure", rootCmd.PersistentFlags().Lookup("insecure"))
+	viper.BindPFlag("api.caFile", rootCmd.PersistentFlags().Lookup("ca-file"))
+	viper.BindPFlag("api.clientCert", rootCmd.PersistentFlags().Lookup("client-cert"))
+	viper.BindPFlag("api.clientKey", rootCmd.PersistentFlags().Lookup("client-key"))
+	viper.BindEnv("api.proxy")
+	viper.BindEnv("api.noProxy")
 	viper.BindPFlag("default.namespace", rootCmd.PersistentFlags().Lookup("namespace"))
 	viper.BindPFlag("output.format", rootCmd.PersistentFlags().Lookup("output"))
+	viper.BindPFlag("api.retries", rootCmd.PersistentFlags().Lookup("retries"))
+	viper.BindPFlag("api.retryWaitTime", rootCmd.PersistentFlags().Lookup("retry-wait"))
+	viper.BindPFlag("api.retryMaxWaitTime", rootCmd.PersistentFlags().Lookup("retry-max-wait"))
+	viper.BindPFlag("api.retryAllMethods", rootCmd.PersistentFlags().Lookup("retry-all-methods"))
 
 	// Add usage command
 	rootCmd.AddCommand(usageCmd)
@@ -87,7 +152,8 @@ func initConfig() {
 
 	// Environment variables
 	viper.SetEnvPrefix("VVP")
-	viper.AutomaticEnv() // read in environment variables that match
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_")) // api.url -> VVP_API_URL
+	viper.AutomaticEnv()                                             // read in environment variables that match
 
 	// If a config file is found, read it in
 	if err := viper.ReadInConfig(); err == nil {
@@ -98,6 +164,12 @@ func initConfig() {
 	var err error
 	cfg, err = config.LoadConfig()
 	if err != nil {
+		// An explicitly requested context that cannot be used is fatal;
+		// anything else is left for the command to report
+		if viper.GetString(config.ContextKey) != "" && errors.Is(err, config.ErrContextNotFound) {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
 		fmt.Fprintf(os.Stderr, "Warning: Failed to load configuration: %v\n", err)
 	}
 }
@@ -106,3 +178,16 @@ func initConfig() {
 func GetConfig() *config.Config {
 	return cfg
 }
+
+// resolveNamespace returns the command's --namespace flag, falling back to the
+// configured default namespace
+func resolveNamespace(cmd *cobra.Command) (string, error) {
+	namespace, _ := cmd.Flags().GetString("namespace")
+	if namespace == "" && cfg != nil {
+		namespace = cfg.Default.Namespace
+	}
+	if namespace == "" {
+		return "", fmt.Errorf("namespace is required")
+	}
+	return namespace, nil
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"text/tabwriter"
@@ -9,7 +8,6 @@ import (
 	"mcolomerc/vvp2cli/pkg/api"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 var savepointCmd = &cobra.Command{
@@ -49,6 +47,14 @@ var savepointDeleteCmd = &cobra.Command{
 	RunE:    runSavepointDelete,
 }
 
+var savepointWaitCmd = &cobra.Command{
+	Use:   "wait [savepointId]",
+	Short: "Wait for a savepoint to complete",
+	Long:  `Poll a savepoint until its state is COMPLETED. Fails immediately if the savepoint FAILED.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSavepointWait,
+}
+
 func init() {
 	rootCmd.AddCommand(savepointCmd)
 
@@ -56,17 +62,24 @@ func init() {
 	savepointCmd.AddCommand(savepointGetCmd)
 	savepointCmd.AddCommand(savepointCreateCmd)
 	savepointCmd.AddCommand(savepointDeleteCmd)
+	savepointCmd.AddCommand(savepointWaitCmd)
 
 	// Add flags
 	savepointListCmd.Flags().StringP("namespace", "n", "", "Namespace")
+	addSelectorFlag(savepointListCmd)
 	savepointGetCmd.Flags().StringP("namespace", "n", "", "Namespace")
 
 	savepointCreateCmd.Flags().StringP("namespace", "n", "", "Namespace")
 	savepointCreateCmd.Flags().String("deployment-id", "", "Deployment ID to create savepoint for")
 	savepointCreateCmd.Flags().String("job-id", "", "Job ID to create savepoint for")
 	savepointCreateCmd.Flags().String("name", "", "Optional name for the savepoint")
+	savepointCreateCmd.Flags().Bool("wait", false, "Wait until the savepoint is COMPLETED")
+	savepointCreateCmd.Flags().Duration("timeout", defaultWaitTimeout, "Maximum time to wait with --wait")
 
 	savepointDeleteCmd.Flags().StringP("namespace", "n", "", "Namespace")
+
+	savepointWaitCmd.Flags().StringP("namespace", "n", "", "Namespace")
+	savepointWaitCmd.Flags().Duration("timeout", defaultWaitTimeout, "Maximum time to wait")
 }
 
 func runSavepointList(cmd *cobra.Command, args []string) error {
@@ -78,17 +91,24 @@ func runSavepointList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("namespace is required")
 	}
 
+	selector, err := labelSelector(cmd)
+	if err != nil {
+		return err
+	}
+
 	client, err := api.NewClient(GetConfig())
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	savepoints, err := client.ListSavepoints(namespace)
+	savepoints, err := client.ListSavepointsContext(cmd.Context(), namespace)
 	if err != nil {
 		return err
 	}
 
-	return printSavepoints(savepoints.Items)
+	return printSavepoints(filterByLabels(savepoints.Items, selector, func(sp api.Savepoint) map[string]string {
+		return sp.Metadata.Labels
+	}))
 }
 
 func runSavepointGet(cmd *cobra.Command, args []string) error {
@@ -106,7 +126,7 @@ func runSavepointGet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	savepoint, err := client.GetSavepoint(namespace, savepointID)
+	savepoint, err := client.GetSavepointContext(cmd.Context(), namespace, savepointID)
 	if err != nil {
 		return err
 	}
@@ -154,12 +174,21 @@ func runSavepointCreate(cmd *cobra.Command, args []string) error {
 		request.Metadata.Name = name
 	}
 
-	result, err := client.CreateSavepoint(namespace, request)
+	result, err := client.CreateSavepointContext(cmd.Context(), namespace, request)
 	if err != nil {
 		return err
 	}
 
 	fmt.Printf("Savepoint creation initiated: %s\n", result.Metadata.ID)
+
+	if wait, _ := cmd.Flags().GetBool("wait"); wait {
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		fmt.Printf("Waiting for savepoint %s to complete...\n", result.Metadata.ID)
+		result, err = client.WaitForSavepointContext(cmd.Context(), namespace, result.Metadata.ID, waitOptions(timeout))
+		if err != nil {
+			return err
+		}
+	}
 	return printSavepoint(result)
 }
 
@@ -178,7 +207,7 @@ func runSavepointDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	if err := client.DeleteSavepoint(namespace, savepointID); err != nil {
+	if err := client.DeleteSavepointContext(cmd.Context(), namespace, savepointID); err != nil {
 		return err
 	}
 
@@ -186,24 +215,37 @@ func runSavepointDelete(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runSavepointWait(cmd *cobra.Command, args []string) error {
+	savepointID := args[0]
+	namespace, _ := cmd.Flags().GetString("namespace")
+	if namespace == "" {
+		namespace = cfg.Default.Namespace
+	}
+	if namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	savepoint, err := client.WaitForSavepointContext(cmd.Context(), namespace, savepointID, waitOptions(timeout))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Savepoint '%s' is COMPLETED\n", savepointID)
+	if savepoint.Status.Completed != nil && savepoint.Status.Completed.Location != "" {
+		fmt.Printf("Location: %s\n", savepoint.Status.Completed.Location)
+	}
+	return nil
+}
+
 // Helper functions for printing savepoints
 func printSavepoints(savepoints []api.Savepoint) error {
-	outputFormat, _ := rootCmd.PersistentFlags().GetString("output")
-
-	switch outputFormat {
-	case "json":
-		data, err := json.MarshalIndent(savepoints, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-	case "yaml":
-		data, err := yaml.Marshal(savepoints)
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-	default:
+	return printOutput("Savepoint", savepoints, func() error {
 		// Table format
 		if len(savepoints) == 0 {
 			fmt.Println("No savepoints found")
@@ -243,28 +285,12 @@ func printSavepoints(savepoints []api.Savepoint) error {
 				created,
 			)
 		}
-		w.Flush()
-	}
-	return nil
+		return w.Flush()
+	})
 }
 
 func printSavepoint(sp *api.Savepoint) error {
-	outputFormat, _ := rootCmd.PersistentFlags().GetString("output")
-
-	switch outputFormat {
-	case "json":
-		data, err := json.MarshalIndent(sp, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-	case "yaml":
-		data, err := yaml.Marshal(sp)
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-	default:
+	return printOutput("Savepoint", sp, func() error {
 		// Table format with details
 		fmt.Printf("Savepoint ID: %s\n", sp.Metadata.ID)
 		if sp.Metadata.Name != "" {
@@ -323,6 +349,6 @@ func printSavepoint(sp *api.Savepoint) error {
 		if !sp.Metadata.ModifiedAt.IsZero() {
 			fmt.Printf("Modified At: %s\n", sp.Metadata.ModifiedAt.Format("2006-01-02 15:04:05"))
 		}
-	}
-	return nil
+		return nil
+	})
 }
@@ -1,15 +1,14 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"text/tabwriter"
 
 	"mcolomerc/vvp2cli/pkg/api"
+	"mcolomerc/vvp2cli/pkg/manifest"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 var secretValueCmd = &cobra.Command{
@@ -65,14 +64,16 @@ func init() {
 
 	// Add flags
 	secretValueListCmd.Flags().StringP("namespace", "n", "", "Namespace")
+	addSelectorFlag(secretValueListCmd)
 	secretValueGetCmd.Flags().StringP("namespace", "n", "", "Namespace")
 
 	secretValueCreateCmd.Flags().StringP("namespace", "n", "", "Namespace")
-	secretValueCreateCmd.Flags().StringP("file", "f", "", "File containing secret value definition")
+	secretValueCreateCmd.Flags().StringP("file", "f", "", "File, directory, or - for stdin containing secret value definitions")
+	secretValueCreateCmd.Flags().BoolP("recursive", "R", false, "Process the directory used in -f recursively")
 	secretValueCreateCmd.MarkFlagRequired("file")
 
 	secretValueUpdateCmd.Flags().StringP("namespace", "n", "", "Namespace")
-	secretValueUpdateCmd.Flags().StringP("file", "f", "", "File containing secret value definition")
+	secretValueUpdateCmd.Flags().StringP("file", "f", "", "File (or - for stdin) containing secret value definition")
 	secretValueUpdateCmd.MarkFlagRequired("file")
 
 	secretValueDeleteCmd.Flags().StringP("namespace", "n", "", "Namespace")
@@ -87,17 +88,24 @@ func runSecretValueList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("namespace is required")
 	}
 
+	selector, err := labelSelector(cmd)
+	if err != nil {
+		return err
+	}
+
 	client, err := api.NewClient(GetConfig())
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	secretValues, err := client.ListSecretValues(namespace)
+	secretValues, err := client.ListSecretValuesContext(cmd.Context(), namespace)
 	if err != nil {
 		return err
 	}
 
-	return printSecretValues(secretValues.Items)
+	return printSecretValues(filterByLabels(secretValues.Items, selector, func(sv api.SecretValue) map[string]string {
+		return sv.Metadata.Labels
+	}))
 }
 
 func runSecretValueGet(cmd *cobra.Command, args []string) error {
@@ -115,7 +123,7 @@ func runSecretValueGet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	secretValue, err := client.GetSecretValue(namespace, name)
+	secretValue, err := client.GetSecretValueContext(cmd.Context(), namespace, name)
 	if err != nil {
 		return err
 	}
@@ -133,19 +141,10 @@ func runSecretValueCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	filename, _ := cmd.Flags().GetString("file")
-	data, err := os.ReadFile(filename)
+	recursive, _ := cmd.Flags().GetBool("recursive")
+	secretValues, err := loadSecretValuesFromFile(filename, recursive)
 	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
-	}
-
-	var secretValue api.SecretValue
-	if err := yaml.Unmarshal(data, &secretValue); err != nil {
-		return fmt.Errorf("failed to parse YAML: %w", err)
-	}
-
-	// Set namespace from flag if not in file
-	if secretValue.Metadata.Namespace == "" {
-		secretValue.Metadata.Namespace = namespace
+		return err
 	}
 
 	client, err := api.NewClient(GetConfig())
@@ -153,13 +152,23 @@ func runSecretValueCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	result, err := client.CreateSecretValue(namespace, &secretValue)
-	if err != nil {
-		return err
-	}
+	for _, secretValue := range secretValues {
+		// Set namespace from flag if not in file
+		if secretValue.Metadata.Namespace == "" {
+			secretValue.Metadata.Namespace = namespace
+		}
 
-	fmt.Printf("Secret value '%s' created successfully\n", result.Metadata.Name)
-	return printSecretValue(result)
+		result, err := client.CreateSecretValueContext(cmd.Context(), namespace, secretValue)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Secret value '%s' created successfully\n", result.Metadata.Name)
+		if err := printSecretValue(result); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func runSecretValueUpdate(cmd *cobra.Command, args []string) error {
@@ -173,14 +182,9 @@ func runSecretValueUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	filename, _ := cmd.Flags().GetString("file")
-	data, err := os.ReadFile(filename)
+	secretValue, err := loadSecretValueFromFile(filename)
 	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
-	}
-
-	var secretValue api.SecretValue
-	if err := yaml.Unmarshal(data, &secretValue); err != nil {
-		return fmt.Errorf("failed to parse YAML: %w", err)
+		return err
 	}
 
 	// Set namespace from flag if not in file
@@ -193,7 +197,7 @@ func runSecretValueUpdate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	result, err := client.UpdateSecretValue(namespace, name, &secretValue)
+	result, err := client.UpdateSecretValueContext(cmd.Context(), namespace, name, secretValue)
 	if err != nil {
 		return err
 	}
@@ -217,7 +221,7 @@ func runSecretValueDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	if err := client.DeleteSecretValue(namespace, name); err != nil {
+	if err := client.DeleteSecretValueContext(cmd.Context(), namespace, name); err != nil {
 		return err
 	}
 
@@ -227,22 +231,7 @@ func runSecretValueDelete(cmd *cobra.Command, args []string) error {
 
 // Helper functions for printing secret values
 func printSecretValues(secretValues []api.SecretValue) error {
-	outputFormat, _ := rootCmd.PersistentFlags().GetString("output")
-
-	switch outputFormat {
-	case "json":
-		data, err := json.MarshalIndent(secretValues, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-	case "yaml":
-		data, err := yaml.Marshal(secretValues)
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-	default:
+	return printOutput(manifest.KindSecretValue, secretValues, func() error {
 		// Table format
 		if len(secretValues) == 0 {
 			fmt.Println("No secret values found")
@@ -269,28 +258,12 @@ func printSecretValues(secretValues []api.SecretValue) error {
 				created,
 			)
 		}
-		w.Flush()
-	}
-	return nil
+		return w.Flush()
+	})
 }
 
 func printSecretValue(sv *api.SecretValue) error {
-	outputFormat, _ := rootCmd.PersistentFlags().GetString("output")
-
-	switch outputFormat {
-	case "json":
-		data, err := json.MarshalIndent(sv, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-	case "yaml":
-		data, err := yaml.Marshal(sv)
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-	default:
+	return printOutput(manifest.KindSecretValue, sv, func() error {
 		// Table format with details (but don't print the actual secret value!)
 		fmt.Printf("Name: %s\n", sv.Metadata.Name)
 		if sv.Metadata.ID != "" {
@@ -327,6 +300,6 @@ func printSecretValue(sv *api.SecretValue) error {
 		if !sv.Metadata.ModifiedAt.IsZero() {
 			fmt.Printf("Modified At: %s\n", sv.Metadata.ModifiedAt.Format("2006-01-02 15:04:05"))
 		}
-	}
-	return nil
+		return nil
+	})
 }
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"mcolomerc/vvp2cli/pkg/labels"
+
+	"github.com/spf13/cobra"
+)
+
+// addSelectorFlag adds -l/--selector to a list command
+func addSelectorFlag(c *cobra.Command) {
+	c.Flags().StringP("selector", "l", "", "Label selector to filter on (e.g. team=data-platform,type!=adhoc; supports =, ==, !=, in, notin and existence)")
+}
+
+// labelSelector parses the --selector flag of cmd
+func labelSelector(cmd *cobra.Command) (labels.Selector, error) {
+	value, _ := cmd.Flags().GetString("selector")
+	return labels.Parse(value)
+}
+
+// filterByLabels keeps the items whose labels, as returned by labelsOf, match
+// selector. It filters client-side for resources whose list endpoint does not
+// take a labelSelector.
+func filterByLabels[T any](items []T, selector labels.Selector, labelsOf func(T) map[string]string) []T {
+	if selector.Empty() {
+		return items
+	}
+	matched := make([]T, 0, len(items))
+	for _, item := range items {
+		if selector.Matches(labelsOf(item)) {
+			matched = append(matched, item)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"mcolomerc/vvp2cli/pkg/api"
+	"mcolomerc/vvp2cli/pkg/manifest"
+
+	"github.com/spf13/cobra"
+)
+
+var sessionCmd = &cobra.Command{
+	Use:     "session",
+	Aliases: []string{"sessions"},
+	Short:   "Manage VVP sessions",
+	Long: `Manage Ververica Platform SQL sessions.
+
+Sessions are served under /api/v1/namespaces/{ns}/sessions, which not every
+VVP version provides; if the server answers 404, use 'vvp2 sessioncluster'.`,
+}
+
+var sessionListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List sessions in a namespace",
+	RunE:    runSessionList,
+}
+
+var sessionGetCmd = &cobra.Command{
+	Use:   "get [name]",
+	Short: "Get a session by name",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSessionGet,
+}
+
+var sessionCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a session from a file",
+	RunE:  runSessionCreate,
+}
+
+var sessionUpdateCmd = &cobra.Command{
+	Use:   "update [name]",
+	Short: "Update a session",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSessionUpdate,
+}
+
+var sessionDeleteCmd = &cobra.Command{
+	Use:     "delete [name]",
+	Aliases: []string{"rm"},
+	Short:   "Delete a session",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runSessionDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(sessionCmd)
+
+	sessionCmd.AddCommand(sessionListCmd)
+	sessionCmd.AddCommand(sessionGetCmd)
+	sessionCmd.AddCommand(sessionCreateCmd)
+	sessionCmd.AddCommand(sessionUpdateCmd)
+	sessionCmd.AddCommand(sessionDeleteCmd)
+
+	// Add flags
+	sessionListCmd.Flags().StringP("namespace", "n", "", "Namespace")
+	sessionGetCmd.Flags().StringP("namespace", "n", "", "Namespace")
+	sessionCreateCmd.Flags().StringP("namespace", "n", "", "Namespace")
+	sessionCreateCmd.Flags().StringP("file", "f", "", "File, directory, or - for stdin containing session definitions")
+	sessionCreateCmd.Flags().BoolP("recursive", "R", false, "Process the directory used in -f recursively")
+	sessionCreateCmd.MarkFlagRequired("file")
+	sessionUpdateCmd.Flags().StringP("namespace", "n", "", "Namespace")
+	sessionUpdateCmd.Flags().StringP("file", "f", "", "File (or - for stdin) containing session definition")
+	sessionUpdateCmd.MarkFlagRequired("file")
+	sessionDeleteCmd.Flags().StringP("namespace", "n", "", "Namespace")
+}
+
+func runSessionList(cmd *cobra.Command, args []string) error {
+	namespace, _ := cmd.Flags().GetString("namespace")
+	if namespace == "" {
+		namespace = cfg.Default.Namespace
+	}
+	if namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	sessions, err := client.ListSessionsContext(cmd.Context(), namespace)
+	if err != nil {
+		return err
+	}
+
+	return printSessions(sessions.Items)
+}
+
+func runSessionGet(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	namespace, _ := cmd.Flags().GetString("namespace")
+	if namespace == "" {
+		namespace = cfg.Default.Namespace
+	}
+	if namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	session, err := client.GetSessionContext(cmd.Context(), namespace, name)
+	if err != nil {
+		return err
+	}
+
+	return printSession(session)
+}
+
+func runSessionCreate(cmd *cobra.Command, args []string) error {
+	namespace, _ := cmd.Flags().GetString("namespace")
+	if namespace == "" {
+		namespace = cfg.Default.Namespace
+	}
+	if namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+
+	filename, _ := cmd.Flags().GetString("file")
+	recursive, _ := cmd.Flags().GetBool("recursive")
+	sessions, err := loadSessionsFromFile(filename, recursive)
+	if err != nil {
+		return err
+	}
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	for _, session := range sessions {
+		// Set namespace from flag if not in file
+		if session.Metadata.Namespace == "" {
+			session.Metadata.Namespace = namespace
+		}
+
+		result, err := client.CreateSessionContext(cmd.Context(), namespace, session)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Session '%s' created successfully\n", result.Metadata.Name)
+		if err := printSession(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runSessionUpdate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	namespace, _ := cmd.Flags().GetString("namespace")
+	if namespace == "" {
+		namespace = cfg.Default.Namespace
+	}
+	if namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+
+	filename, _ := cmd.Flags().GetString("file")
+	session, err := loadSessionFromFile(filename)
+	if err != nil {
+		return err
+	}
+
+	// Set namespace from flag if not in file
+	if session.Metadata.Namespace == "" {
+		session.Metadata.Namespace = namespace
+	}
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	result, err := client.UpdateSessionContext(cmd.Context(), namespace, name, session)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Session '%s' updated successfully\n", result.Metadata.Name)
+	return printSession(result)
+}
+
+func runSessionDelete(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	namespace, _ := cmd.Flags().GetString("namespace")
+	if namespace == "" {
+		namespace = cfg.Default.Namespace
+	}
+	if namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	if err := client.DeleteSessionContext(cmd.Context(), namespace, name); err != nil {
+		return err
+	}
+
+	fmt.Printf("Session '%s' deleted successfully\n", name)
+	return nil
+}
+
+func loadSessionFromFile(filename string) (*api.Session, error) {
+	obj, err := loadManifestObject(filename, manifest.KindSession)
+	if err != nil {
+		return nil, err
+	}
+
+	var session api.Session
+	if err := obj.Into(&session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func loadSessionsFromFile(filename string, recursive bool) ([]*api.Session, error) {
+	objs, err := loadManifestObjects(filename, manifest.KindSession, recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*api.Session, 0, len(objs))
+	for _, obj := range objs {
+		var session api.Session
+		if err := obj.Into(&session); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &session)
+	}
+	return sessions, nil
+}
+
+// Helper functions for printing sessions
+func printSessions(sessions []api.Session) error {
+	return printOutput(manifest.KindSession, sessions, func() error {
+		// Table format
+		if len(sessions) == 0 {
+			fmt.Println("No sessions found")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "NAME\tNAMESPACE\tSTATE\tDEPLOYMENT TARGET ID\tFLINK VERSION\tCPU\tMEMORY")
+		for _, s := range sessions {
+			profile := s.Spec.SessionClusterResourceProfile
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				s.Metadata.Name,
+				s.Metadata.Namespace,
+				orDash(s.Status.State),
+				orDash(s.Spec.DeploymentTargetID),
+				orDash(s.Spec.FlinkVersion),
+				orDash(profile.CPU),
+				orDash(profile.Memory),
+			)
+		}
+		return w.Flush()
+	})
+}
+
+func printSession(s *api.Session) error {
+	return printOutput(manifest.KindSession, s, func() error {
+		// Table format with details
+		fmt.Printf("Name: %s\n", s.Metadata.Name)
+		fmt.Printf("Namespace: %s\n", s.Metadata.Namespace)
+		if s.Metadata.ID != "" {
+			fmt.Printf("ID: %s\n", s.Metadata.ID)
+		}
+		fmt.Printf("State: %s\n", orDash(s.Status.State))
+		fmt.Printf("Deployment Target ID: %s\n", orDash(s.Spec.DeploymentTargetID))
+		fmt.Printf("Flink Version: %s\n", orDash(s.Spec.FlinkVersion))
+
+		profile := s.Spec.SessionClusterResourceProfile
+		if profile.CPU != "" || profile.Memory != "" {
+			fmt.Println("\nResource Profile:")
+			fmt.Printf("  CPU: %s\n", orDash(profile.CPU))
+			fmt.Printf("  Memory: %s\n", orDash(profile.Memory))
+		}
+
+		if len(s.Spec.FlinkConfiguration) > 0 {
+			fmt.Println("\nFlink Configuration:")
+			for k, v := range s.Spec.FlinkConfiguration {
+				fmt.Printf("  %s: %s\n", k, v)
+			}
+		}
+
+		if len(s.Metadata.Labels) > 0 {
+			fmt.Println("\nLabels:")
+			for k, v := range s.Metadata.Labels {
+				fmt.Printf("  %s: %s\n", k, v)
+			}
+		}
+
+		if !s.Metadata.CreatedAt.IsZero() {
+			fmt.Printf("\nCreated At: %s\n", s.Metadata.CreatedAt.Format("2006-01-02 15:04:05"))
+		}
+		if !s.Metadata.ModifiedAt.IsZero() {
+			fmt.Printf("Modified At: %s\n", s.Metadata.ModifiedAt.Format("2006-01-02 15:04:05"))
+		}
+		return nil
+	})
+}
+
+// orDash returns s, or "-" for empty table cells
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
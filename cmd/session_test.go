@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSessionsFromFileUnquotedNumbers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.yaml")
+	err := os.WriteFile(path, []byte(`
+metadata:
+  name: test-session
+spec:
+  flinkVersion: 1.20
+  sessionClusterResourceProfile:
+    cpu: 2
+    memory: 4g
+  flinkConfiguration:
+    taskmanager.numberOfTaskSlots: 4
+---
+kind: Session
+metadata:
+  name: other-session
+spec:
+  flinkVersion: 1.19
+`), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	sessions, err := loadSessionsFromFile(path, false)
+	if err != nil {
+		t.Fatalf("Failed to load sessions: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("Expected 2 sessions, got %d", len(sessions))
+	}
+
+	spec := sessions[0].Spec
+	if spec.FlinkVersion != "1.20" {
+		t.Errorf("Expected flinkVersion '1.20', got '%s'", spec.FlinkVersion)
+	}
+	if spec.SessionClusterResourceProfile.CPU != "2" {
+		t.Errorf("Expected cpu '2', got '%s'", spec.SessionClusterResourceProfile.CPU)
+	}
+	if slots := spec.FlinkConfiguration["taskmanager.numberOfTaskSlots"]; slots != "4" {
+		t.Errorf("Expected taskmanager.numberOfTaskSlots '4', got '%s'", slots)
+	}
+	if sessions[1].Spec.FlinkVersion != "1.19" {
+		t.Errorf("Expected flinkVersion '1.19', got '%s'", sessions[1].Spec.FlinkVersion)
+	}
+}
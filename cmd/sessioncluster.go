@@ -1,15 +1,14 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"text/tabwriter"
 
 	"mcolomerc/vvp2cli/pkg/api"
+	"mcolomerc/vvp2cli/pkg/manifest"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 var sessionClusterCmd = &cobra.Command{
@@ -50,8 +49,26 @@ var sessionClusterDeleteCmd = &cobra.Command{
 	Use:     "delete [name]",
 	Aliases: []string{"rm"},
 	Short:   "Delete a session cluster",
+	Long: `Delete a session cluster.
+
+Deployments running on the cluster (spec.sessionClusterName) would stop with
+it, so the command refuses while any of them is RUNNING or still changing
+state. --suspend suspends them first, which takes a savepoint of each; --force
+deletes the cluster anyway.`,
+	Example: `  vvp2 sessioncluster delete my-sql-session
+  vvp2 sessioncluster delete my-sql-session --suspend --timeout 10m`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSessionClusterDelete,
+}
+
+var sessionClusterWaitCmd = &cobra.Command{
+	Use:   "wait [name]",
+	Short: "Wait for a session cluster to reach a state",
+	Long: `Poll a session cluster until status.state reaches the requested state.
+Fails immediately, reporting the failure reason, if the cluster ends up FAILED.`,
+	Example: `  vvp2 sessioncluster wait my-sql-session --for=state=RUNNING --timeout 10m`,
 	Args:    cobra.ExactArgs(1),
-	RunE:    runSessionClusterDelete,
+	RunE:    runSessionClusterWait,
 }
 
 func init() {
@@ -62,17 +79,25 @@ func init() {
 	sessionClusterCmd.AddCommand(sessionClusterCreateCmd)
 	sessionClusterCmd.AddCommand(sessionClusterUpdateCmd)
 	sessionClusterCmd.AddCommand(sessionClusterDeleteCmd)
+	sessionClusterCmd.AddCommand(sessionClusterWaitCmd)
 
 	// Add flags
 	sessionClusterListCmd.Flags().StringP("namespace", "n", "", "Namespace")
+	addSelectorFlag(sessionClusterListCmd)
 	sessionClusterGetCmd.Flags().StringP("namespace", "n", "", "Namespace")
 	sessionClusterCreateCmd.Flags().StringP("namespace", "n", "", "Namespace")
-	sessionClusterCreateCmd.Flags().StringP("file", "f", "", "File containing session cluster definition")
+	sessionClusterCreateCmd.Flags().StringP("file", "f", "", "File, directory, or - for stdin containing session cluster definitions")
+	sessionClusterCreateCmd.Flags().BoolP("recursive", "R", false, "Process the directory used in -f recursively")
 	sessionClusterCreateCmd.MarkFlagRequired("file")
 	sessionClusterUpdateCmd.Flags().StringP("namespace", "n", "", "Namespace")
-	sessionClusterUpdateCmd.Flags().StringP("file", "f", "", "File containing session cluster definition")
+	sessionClusterUpdateCmd.Flags().StringP("file", "f", "", "File (or - for stdin) containing session cluster definition")
 	sessionClusterUpdateCmd.MarkFlagRequired("file")
 	sessionClusterDeleteCmd.Flags().StringP("namespace", "n", "", "Namespace")
+	sessionClusterDeleteCmd.Flags().Duration("timeout", defaultWaitTimeout, "Maximum time to wait for deployments to suspend with --suspend")
+	addSessionClusterGuardFlags(sessionClusterDeleteCmd)
+	sessionClusterWaitCmd.Flags().StringP("namespace", "n", "", "Namespace")
+	sessionClusterWaitCmd.Flags().String("for", "state=RUNNING", "Condition to wait for (state=<STATE>)")
+	sessionClusterWaitCmd.Flags().Duration("timeout", defaultWaitTimeout, "Maximum time to wait")
 }
 
 func runSessionClusterList(cmd *cobra.Command, args []string) error {
@@ -84,17 +109,24 @@ func runSessionClusterList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("namespace is required")
 	}
 
+	selector, err := labelSelector(cmd)
+	if err != nil {
+		return err
+	}
+
 	client, err := api.NewClient(GetConfig())
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	sessionClusters, err := client.ListSessionClusters(namespace)
+	sessionClusters, err := client.ListSessionClustersContext(cmd.Context(), namespace)
 	if err != nil {
 		return err
 	}
 
-	return printSessionClusters(sessionClusters.Items)
+	return printSessionClusters(filterByLabels(sessionClusters.Items, selector, func(sc api.SessionCluster) map[string]string {
+		return sc.Metadata.Labels
+	}))
 }
 
 func runSessionClusterGet(cmd *cobra.Command, args []string) error {
@@ -112,7 +144,7 @@ func runSessionClusterGet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	sessionCluster, err := client.GetSessionCluster(namespace, name)
+	sessionCluster, err := client.GetSessionClusterContext(cmd.Context(), namespace, name)
 	if err != nil {
 		return err
 	}
@@ -130,14 +162,50 @@ func runSessionClusterCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	filename, _ := cmd.Flags().GetString("file")
-	data, err := os.ReadFile(filename)
+	recursive, _ := cmd.Flags().GetBool("recursive")
+	sessionClusters, err := loadSessionClustersFromFile(filename, recursive)
 	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
+		return err
 	}
 
-	var sessionCluster api.SessionCluster
-	if err := yaml.Unmarshal(data, &sessionCluster); err != nil {
-		return fmt.Errorf("failed to parse YAML: %w", err)
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	for _, sessionCluster := range sessionClusters {
+		// Set namespace from flag if not in file
+		if sessionCluster.Metadata.Namespace == "" {
+			sessionCluster.Metadata.Namespace = namespace
+		}
+
+		result, err := client.CreateSessionClusterContext(cmd.Context(), namespace, sessionCluster)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Session cluster '%s' created successfully\n", result.Metadata.Name)
+		if err := printSessionCluster(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runSessionClusterUpdate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	namespace, _ := cmd.Flags().GetString("namespace")
+	if namespace == "" {
+		namespace = cfg.Default.Namespace
+	}
+	if namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+
+	filename, _ := cmd.Flags().GetString("file")
+	sessionCluster, err := loadSessionClusterFromFile(filename)
+	if err != nil {
+		return err
 	}
 
 	// Set namespace from flag if not in file
@@ -150,16 +218,16 @@ func runSessionClusterCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	result, err := client.CreateSessionCluster(namespace, &sessionCluster)
+	result, err := client.UpdateSessionClusterContext(cmd.Context(), namespace, name, sessionCluster)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Session cluster '%s' created successfully\n", result.Metadata.Name)
+	fmt.Printf("Session cluster '%s' updated successfully\n", result.Metadata.Name)
 	return printSessionCluster(result)
 }
 
-func runSessionClusterUpdate(cmd *cobra.Command, args []string) error {
+func runSessionClusterDelete(cmd *cobra.Command, args []string) error {
 	name := args[0]
 	namespace, _ := cmd.Flags().GetString("namespace")
 	if namespace == "" {
@@ -169,37 +237,56 @@ func runSessionClusterUpdate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("namespace is required")
 	}
 
-	filename, _ := cmd.Flags().GetString("file")
-	data, err := os.ReadFile(filename)
+	client, err := api.NewClient(GetConfig())
 	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
+		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	var sessionCluster api.SessionCluster
-	if err := yaml.Unmarshal(data, &sessionCluster); err != nil {
-		return fmt.Errorf("failed to parse YAML: %w", err)
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	suspended, err := guardSessionClusterDeployments(cmd, client, namespace, name, "delete", timeout)
+	if err != nil {
+		return err
 	}
 
-	// Set namespace from flag if not in file
-	if sessionCluster.Metadata.Namespace == "" {
-		sessionCluster.Metadata.Namespace = namespace
+	if err := client.DeleteSessionClusterContext(cmd.Context(), namespace, name); err != nil {
+		return withSuspended(err, suspended)
 	}
 
-	client, err := api.NewClient(GetConfig())
+	fmt.Printf("Session cluster '%s' deleted successfully\n", name)
+	return nil
+}
+
+func loadSessionClusterFromFile(filename string) (*api.SessionCluster, error) {
+	obj, err := loadManifestObject(filename, manifest.KindSessionCluster)
 	if err != nil {
-		return fmt.Errorf("failed to create API client: %w", err)
+		return nil, err
 	}
 
-	result, err := client.UpdateSessionCluster(namespace, name, &sessionCluster)
+	var sessionCluster api.SessionCluster
+	if err := obj.Into(&sessionCluster); err != nil {
+		return nil, err
+	}
+	return &sessionCluster, nil
+}
+
+func loadSessionClustersFromFile(filename string, recursive bool) ([]*api.SessionCluster, error) {
+	objs, err := loadManifestObjects(filename, manifest.KindSessionCluster, recursive)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	fmt.Printf("Session cluster '%s' updated successfully\n", result.Metadata.Name)
-	return printSessionCluster(result)
+	sessionClusters := make([]*api.SessionCluster, 0, len(objs))
+	for _, obj := range objs {
+		var sessionCluster api.SessionCluster
+		if err := obj.Into(&sessionCluster); err != nil {
+			return nil, err
+		}
+		sessionClusters = append(sessionClusters, &sessionCluster)
+	}
+	return sessionClusters, nil
 }
 
-func runSessionClusterDelete(cmd *cobra.Command, args []string) error {
+func runSessionClusterWait(cmd *cobra.Command, args []string) error {
 	name := args[0]
 	namespace, _ := cmd.Flags().GetString("namespace")
 	if namespace == "" {
@@ -209,37 +296,29 @@ func runSessionClusterDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("namespace is required")
 	}
 
+	condition, _ := cmd.Flags().GetString("for")
+	state, err := parseWaitFor(condition)
+	if err != nil {
+		return err
+	}
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
 	client, err := api.NewClient(GetConfig())
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	if err := client.DeleteSessionCluster(namespace, name); err != nil {
+	if _, err := client.WaitForSessionClusterStateContext(cmd.Context(), namespace, name, state, waitOptions(timeout)); err != nil {
 		return err
 	}
 
-	fmt.Printf("Session cluster '%s' deleted successfully\n", name)
+	fmt.Printf("Session cluster '%s' is %s\n", name, state)
 	return nil
 }
 
 // Helper functions for printing session clusters
 func printSessionClusters(sessionClusters []api.SessionCluster) error {
-	outputFormat, _ := rootCmd.PersistentFlags().GetString("output")
-
-	switch outputFormat {
-	case "json":
-		data, err := json.MarshalIndent(sessionClusters, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-	case "yaml":
-		data, err := yaml.Marshal(sessionClusters)
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-	default:
+	return printOutput(manifest.KindSessionCluster, sessionClusters, func() error {
 		// Table format
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
 		fmt.Fprintln(w, "NAME\tNAMESPACE\tSTATE\tTASKMANAGERS\tFLINK VERSION")
@@ -256,28 +335,12 @@ func printSessionClusters(sessionClusters []api.SessionCluster) error {
 				sc.Spec.FlinkVersion,
 			)
 		}
-		w.Flush()
-	}
-	return nil
+		return w.Flush()
+	})
 }
 
 func printSessionCluster(sc *api.SessionCluster) error {
-	outputFormat, _ := rootCmd.PersistentFlags().GetString("output")
-
-	switch outputFormat {
-	case "json":
-		data, err := json.MarshalIndent(sc, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-	case "yaml":
-		data, err := yaml.Marshal(sc)
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-	default:
+	return printOutput(manifest.KindSessionCluster, sc, func() error {
 		// Table format with details
 		fmt.Printf("Name: %s\n", sc.Metadata.Name)
 		fmt.Printf("Namespace: %s\n", sc.Metadata.Namespace)
@@ -302,6 +365,6 @@ func printSessionCluster(sc *api.SessionCluster) error {
 				fmt.Printf("  %s: %s\n", k, v)
 			}
 		}
-	}
-	return nil
+		return nil
+	})
 }
@@ -0,0 +1,325 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"mcolomerc/vvp2cli/pkg/api"
+
+	"github.com/spf13/cobra"
+)
+
+var sessionClusterStartCmd = &cobra.Command{
+	Use:   "start [name]",
+	Short: "Start a session cluster",
+	Long: `Set spec.state of a session cluster to RUNNING and wait until it is RUNNING.
+Fails, reporting the failure reason, if the cluster ends up FAILED.`,
+	Example: `  vvp2 sessioncluster start my-sql-session
+  vvp2 sessioncluster start my-sql-session --wait=false`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSessionClusterSpecChange(cmd, args[0], func(spec *api.SessionClusterSpec) {
+			spec.State = "RUNNING"
+		})
+	},
+}
+
+var sessionClusterStopCmd = &cobra.Command{
+	Use:   "stop [name]",
+	Short: "Stop a session cluster",
+	Long: `Set spec.state of a session cluster to STOPPED and wait until it is STOPPED.
+
+Deployments running on the cluster (spec.sessionClusterName) would stop with
+it, so the command refuses while any of them is RUNNING or still changing
+state. --suspend suspends them first, which takes a savepoint of each; --force
+stops the cluster anyway.`,
+	Example: `  vvp2 sessioncluster stop my-sql-session
+  vvp2 sessioncluster stop my-sql-session --suspend`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSessionClusterSpecChange(cmd, args[0], func(spec *api.SessionClusterSpec) {
+			spec.State = "STOPPED"
+		})
+	},
+}
+
+var sessionClusterScaleCmd = &cobra.Command{
+	Use:   "scale [name]",
+	Short: "Change the number of TaskManagers of a session cluster",
+	Long: `Set spec.numberOfTaskManagers of a session cluster. A running cluster is
+updated in place and the command waits until it is RUNNING again; a stopped
+cluster keeps the new size for its next start.`,
+	Example: `  vvp2 sessioncluster scale my-sql-session --taskmanagers 4`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		taskManagers, _ := cmd.Flags().GetInt32("taskmanagers")
+		if taskManagers < 1 {
+			return fmt.Errorf("--taskmanagers must be at least 1, got %d", taskManagers)
+		}
+		return runSessionClusterSpecChange(cmd, args[0], func(spec *api.SessionClusterSpec) {
+			spec.NumberOfTaskManagers = taskManagers
+		})
+	},
+}
+
+func init() {
+	sessionClusterCmd.AddCommand(sessionClusterStartCmd)
+	sessionClusterCmd.AddCommand(sessionClusterStopCmd)
+	sessionClusterCmd.AddCommand(sessionClusterScaleCmd)
+
+	for _, c := range []*cobra.Command{sessionClusterStartCmd, sessionClusterStopCmd, sessionClusterScaleCmd} {
+		c.Flags().StringP("namespace", "n", "", "Namespace")
+		c.Flags().Bool("wait", true, "Wait until the session cluster reaches the requested state")
+		c.Flags().Duration("timeout", defaultWaitTimeout, "Maximum time to wait")
+	}
+	addSessionClusterGuardFlags(sessionClusterStopCmd)
+	sessionClusterScaleCmd.Flags().Int32("taskmanagers", 0, "Number of TaskManagers")
+	sessionClusterScaleCmd.MarkFlagRequired("taskmanagers")
+}
+
+// runSessionClusterSpecChange applies change to the current spec of a session
+// cluster, patches it and, unless --wait=false, waits until status.state
+// reaches the resulting spec.state
+func runSessionClusterSpecChange(cmd *cobra.Command, name string, change func(*api.SessionClusterSpec)) error {
+	namespace, _ := cmd.Flags().GetString("namespace")
+	if namespace == "" {
+		namespace = cfg.Default.Namespace
+	}
+	if namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+	wait, _ := cmd.Flags().GetBool("wait")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	client, err := api.NewClient(GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	ctx := cmd.Context()
+	current, err := client.GetSessionClusterContext(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	spec := current.Spec
+	change(&spec)
+	target := spec.State
+	if spec.State == current.Spec.State && spec.NumberOfTaskManagers == current.Spec.NumberOfTaskManagers && current.Status.State == target {
+		fmt.Printf("Session cluster '%s' is already %s with %s\n", name, target, taskManagerCount(spec.NumberOfTaskManagers))
+		return nil
+	}
+
+	var suspended []string
+	if target == "STOPPED" && current.Spec.State != "STOPPED" {
+		suspended, err = guardSessionClusterDeployments(cmd, client, namespace, name, "stop", timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Send metadata and spec only; resourceVersion guards against
+	// overwriting a concurrent change
+	update := &api.SessionCluster{
+		APIVersion: current.APIVersion,
+		Kind:       current.Kind,
+		Metadata:   current.Metadata,
+		Spec:       spec,
+	}
+	result, err := client.UpdateSessionClusterContext(ctx, namespace, name, update)
+	if err != nil {
+		return withSuspended(fmt.Errorf("failed to update session cluster: %w", err), suspended)
+	}
+	fmt.Printf("Session cluster '%s' updated: state %s, %s\n", name, target, taskManagerCount(spec.NumberOfTaskManagers))
+
+	if wait {
+		fmt.Printf("Waiting for session cluster %s to reach %s...\n", name, target)
+		result, err = client.WaitForSessionClusterContext(ctx, namespace, name, waitOptions(timeout),
+			sessionClusterReached(name, target, current, result))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Session cluster '%s' is %s\n", name, target)
+	}
+	return printSessionCluster(result)
+}
+
+// sessionClusterReached reports when a session cluster's status.state equals
+// target and fails if the cluster ends up FAILED. When the cluster was
+// already FAILED before the change (e.g. restarting it), that stale failure is
+// waited out until the cluster leaves FAILED or reports a different failure;
+// if it never does, the timeout error still includes the failure.
+//
+// A running cluster updated in place (e.g. scaled) still reports RUNNING
+// right after the update, so RUNNING only counts once the cluster has shown
+// the change: another state such as UPDATING, a newer
+// status.running.transitionTime or a resourceVersion newer than updated.
+func sessionClusterReached(name, target string, before, updated *api.SessionCluster) func(*api.SessionCluster) (bool, error) {
+	var staleFailure *api.Failure
+	if before.Status.State == "FAILED" {
+		staleFailure = before.Status.Failure
+		if staleFailure == nil {
+			staleFailure = &api.Failure{}
+		}
+	}
+	inPlace := target == "RUNNING" && before.Spec.State == "RUNNING" && before.Status.State == "RUNNING"
+	return func(sc *api.SessionCluster) (bool, error) {
+		switch sc.Status.State {
+		case target:
+			if inPlace && !sessionClusterChanged(before, updated, sc) {
+				return false, nil
+			}
+			return true, nil
+		case "FAILED":
+			if staleFailure != nil && sameFailure(staleFailure, sc.Status.Failure) {
+				return false, nil
+			}
+			return false, api.SessionClusterFailedError(name, sc.Status.Failure)
+		}
+		staleFailure = nil
+		inPlace = false
+		return false, nil
+	}
+}
+
+// sessionClusterChanged reports whether sc was modified after updated, or
+// transitioned after before
+func sessionClusterChanged(before, updated, sc *api.SessionCluster) bool {
+	if updated != nil && updated.Metadata.ResourceVersion != 0 && sc.Metadata.ResourceVersion > updated.Metadata.ResourceVersion {
+		return true
+	}
+	return runningSince(sc).After(runningSince(before))
+}
+
+// runningSince returns status.running.transitionTime, if set
+func runningSince(sc *api.SessionCluster) time.Time {
+	if sc.Status.Running == nil {
+		return time.Time{}
+	}
+	return sc.Status.Running.TransitionTime
+}
+
+// sameFailure reports whether failure is the one reported as stale
+func sameFailure(stale, failure *api.Failure) bool {
+	if failure == nil {
+		failure = &api.Failure{}
+	}
+	return failure.Reason == stale.Reason && failure.Message == stale.Message && failure.Time.Equal(stale.Time)
+}
+
+// addSessionClusterGuardFlags adds the flags of guardSessionClusterDeployments
+func addSessionClusterGuardFlags(c *cobra.Command) {
+	c.Flags().Bool("force", false, "Proceed even if deployments are running on the session cluster")
+	c.Flags().Bool("suspend", false, "Suspend deployments running on the session cluster first (with a savepoint)")
+}
+
+// guardSessionClusterDeployments refuses to stop or delete a session cluster
+// while deployments referencing it are active, unless --force is given. With
+// --suspend, those deployments are suspended and awaited first; the names of
+// the suspended deployments are returned so that a failure of the following
+// cluster update can mention them.
+func guardSessionClusterDeployments(cmd *cobra.Command, client *api.Client, namespace, name, action string, timeout time.Duration) ([]string, error) {
+	force, _ := cmd.Flags().GetBool("force")
+	suspend, _ := cmd.Flags().GetBool("suspend")
+
+	ctx := cmd.Context()
+	deployments, err := client.ListSessionClusterDeploymentsContext(ctx, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments on session cluster %s: %w", name, err)
+	}
+	var active []api.Deployment
+	for _, d := range deployments {
+		if deploymentActive(d) {
+			active = append(active, d)
+		}
+	}
+	if len(active) == 0 {
+		return nil, nil
+	}
+
+	if suspend {
+		// Suspend the deployments meant to run; the others are already on
+		// their way down (e.g. cancelling) and are only awaited
+		var suspended []string
+		for _, d := range active {
+			if d.Spec.State != "RUNNING" {
+				continue
+			}
+			if _, err := client.UpdateDeploymentStateContext(ctx, namespace, d.Metadata.Name, "SUSPENDED"); err != nil {
+				return suspended, withSuspended(fmt.Errorf("failed to suspend deployment %s: %w", d.Metadata.Name, err), suspended)
+			}
+			suspended = append(suspended, d.Metadata.Name)
+			fmt.Printf("Suspending deployment %s...\n", d.Metadata.Name)
+		}
+		for _, d := range active {
+			if d.Spec.State == "RUNNING" {
+				_, err = client.WaitForDeploymentStateContext(ctx, namespace, d.Metadata.Name, "SUSPENDED", waitOptions(timeout))
+			} else {
+				_, err = client.WaitForDeploymentStoppedContext(ctx, namespace, d.Metadata.Name, waitOptions(timeout))
+			}
+			if err != nil {
+				return suspended, withSuspended(err, suspended)
+			}
+			fmt.Printf("Deployment %s is %s\n", d.Metadata.Name, deploymentStoppedState(d))
+		}
+		return suspended, nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Session cluster '%s' hosts %d active deployment(s):\n", name, len(active))
+	for _, d := range active {
+		state := d.Spec.State
+		if d.Status != nil && d.Status.State != "" {
+			state = d.Status.State
+		}
+		fmt.Fprintf(os.Stderr, "  - %s (%s)\n", d.Metadata.Name, state)
+	}
+	if force {
+		fmt.Fprintf(os.Stderr, "Proceeding because of --force; these deployments will stop\n")
+		return nil, nil
+	}
+	return nil, fmt.Errorf("refusing to %s session cluster %s while deployments run on it (use --suspend to suspend them first, or --force)", action, name)
+}
+
+// deploymentActive reports whether a deployment runs, or is about to run or
+// stop, on its session cluster: spec.state is RUNNING or status.state is not
+// terminal (e.g. TRANSITIONING)
+func deploymentActive(d api.Deployment) bool {
+	if d.Spec.State == "RUNNING" {
+		return true
+	}
+	if d.Status == nil {
+		return false
+	}
+	switch d.Status.State {
+	case "", "CANCELLED", "SUSPENDED", "FAILED", "FINISHED":
+		return false
+	}
+	return true
+}
+
+// deploymentStoppedState is the state an active deployment is awaited in
+func deploymentStoppedState(d api.Deployment) string {
+	if d.Spec.State == "RUNNING" {
+		return "SUSPENDED"
+	}
+	return "stopped"
+}
+
+// withSuspended adds the deployments that were suspended before err to it, as
+// they stay SUSPENDED
+func withSuspended(err error, suspended []string) error {
+	if len(suspended) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w (these deployments were suspended and stay SUSPENDED: %s)", err, strings.Join(suspended, ", "))
+}
+
+func taskManagerCount(n int32) string {
+	if n == 1 {
+		return "1 TaskManager"
+	}
+	return strconv.Itoa(int(n)) + " TaskManagers"
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mcolomerc/vvp2cli/pkg/api"
+	"mcolomerc/vvp2cli/pkg/config"
+)
+
+func TestDeploymentActive(t *testing.T) {
+	tests := []struct {
+		spec, status string
+		want         bool
+	}{
+		{"RUNNING", "RUNNING", true},
+		{"RUNNING", "TRANSITIONING", true},
+		{"RUNNING", "", true},
+		{"CANCELLED", "TRANSITIONING", true},
+		{"SUSPENDED", "RUNNING", true},
+		{"SUSPENDED", "SUSPENDED", false},
+		{"CANCELLED", "CANCELLED", false},
+		{"RUNNING", "FAILED", true},
+		{"CANCELLED", "FAILED", false},
+		{"CANCELLED", "FINISHED", false},
+		{"SUSPENDED", "", false},
+	}
+	for _, tt := range tests {
+		d := api.Deployment{Spec: api.DeploymentSpec{State: tt.spec}}
+		if tt.status != "" {
+			d.Status = &api.DeploymentStatus{State: tt.status}
+		}
+		if got := deploymentActive(d); got != tt.want {
+			t.Errorf("spec %s, status %s: expected active %v, got %v", tt.spec, tt.status, tt.want, got)
+		}
+	}
+}
+
+func TestSessionClusterReachedStaleFailure(t *testing.T) {
+	failed := func(reason string, at time.Time) *api.SessionCluster {
+		return &api.SessionCluster{Status: api.SessionClusterStatus{State: "FAILED", Failure: &api.Failure{Reason: reason, Time: at}}}
+	}
+	earlier := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	starting := &api.SessionCluster{Status: api.SessionClusterStatus{State: "STARTING"}}
+
+	// The failure from before the restart is waited out
+	reached := sessionClusterReached("sql", "RUNNING", failed("OOM", earlier), nil)
+	if done, err := reached(failed("OOM", earlier)); done || err != nil {
+		t.Errorf("Expected the stale failure to be waited out, got %v, %v", done, err)
+	}
+	// A new failure while still FAILED is reported
+	if _, err := reached(failed("OOM", earlier.Add(time.Minute))); err == nil {
+		t.Error("Expected a new failure to be reported")
+	}
+
+	// Once the cluster left FAILED, the same failure counts again
+	reached = sessionClusterReached("sql", "RUNNING", failed("OOM", earlier), nil)
+	reached(starting)
+	if _, err := reached(failed("OOM", earlier)); err == nil {
+		t.Error("Expected FAILED after leaving it to be reported")
+	}
+
+	// A cluster that was not FAILED fails on the first FAILED state
+	reached = sessionClusterReached("sql", "RUNNING", starting, nil)
+	if _, err := reached(failed("OOM", earlier)); err == nil {
+		t.Error("Expected FAILED to be reported")
+	}
+}
+
+func TestSessionClusterReachedWaitsForInPlaceUpdate(t *testing.T) {
+	cluster := func(state string, version int32) api.SessionCluster {
+		return api.SessionCluster{
+			Metadata: api.SessionClusterMetadata{Name: "sql", ResourceVersion: version},
+			Spec:     api.SessionClusterSpec{State: "RUNNING"},
+			Status:   api.SessionClusterStatus{State: state},
+		}
+	}
+	before, updated := cluster("RUNNING", 4), cluster("RUNNING", 5)
+
+	// The first polls still return the RUNNING state from before the scale
+	polls := []api.SessionCluster{cluster("RUNNING", 5), cluster("RUNNING", 5), cluster("UPDATING", 6), cluster("RUNNING", 7)}
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sc := polls[min(calls, len(polls)-1)]
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sc)
+	}))
+	defer server.Close()
+
+	client, err := api.NewClient(&config.Config{API: config.APIConfig{URL: server.URL}})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	result, err := client.WaitForSessionClusterContext(context.Background(), "default", "sql",
+		api.WaitOptions{Timeout: 2 * time.Second, Interval: 10 * time.Millisecond},
+		sessionClusterReached("sql", "RUNNING", &before, &updated))
+	if err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	if calls != 4 || result.Metadata.ResourceVersion != 7 {
+		t.Errorf("Expected to wait for the RUNNING state after UPDATING, stopped after %d polls at version %d", calls, result.Metadata.ResourceVersion)
+	}
+
+	// A newer transition time also shows the update was applied
+	running := cluster("RUNNING", 5)
+	running.Status.Running = &api.SessionClusterStatusRunning{TransitionTime: time.Now()}
+	if done, _ := sessionClusterReached("sql", "RUNNING", &before, &updated)(&running); !done {
+		t.Error("Expected RUNNING with a newer transition time to be reached")
+	}
+
+	// Scaling a stopped cluster changes nothing at runtime
+	stopped := cluster("STOPPED", 4)
+	stopped.Spec.State = "STOPPED"
+	if done, _ := sessionClusterReached("sql", "STOPPED", &stopped, &updated)(&stopped); !done {
+		t.Error("Expected a stopped cluster to stay reached")
+	}
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"text/tabwriter"
@@ -9,14 +8,16 @@ import (
 	"mcolomerc/vvp2cli/pkg/api"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Get VVP platform status",
-	Long:  `Retrieve the status of the Ververica Platform, including health, version, and component information.`,
-	RunE:  runStatus,
+	Long: `Retrieve the status of the Ververica Platform, including health, version, and component information.
+
+The connection section shows how vvp2 reaches the API: the TLS mode (none,
+system, custom-ca, mutual or insecure) and the proxy in use, if any.`,
+	RunE: runStatus,
 }
 
 func init() {
@@ -29,31 +30,24 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	status, err := client.GetStatus()
+	status, err := client.GetStatusContext(cmd.Context())
 	if err != nil {
 		return err
 	}
 
-	return printStatus(status)
+	return printStatus(status, api.DescribeConnection(GetConfig()))
 }
 
-func printStatus(status *api.Status) error {
-	outputFormat, _ := rootCmd.PersistentFlags().GetString("output")
+// statusOutput adds the client connection details to the platform status
+type statusOutput struct {
+	*api.Status `yaml:",inline"`
+	Connection  api.ConnectionInfo `json:"connection" yaml:"connection"`
+}
 
-	switch outputFormat {
-	case "json":
-		data, err := json.MarshalIndent(status, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-	case "yaml":
-		data, err := yaml.Marshal(status)
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
-	default:
+func printStatus(status *api.Status, conn api.ConnectionInfo) error {
+	output := statusOutput{Status: status, Connection: conn}
+
+	return printOutput("", output, func() error {
 		// Table format with details
 		fmt.Println("=== Platform Status ===")
 
@@ -86,6 +80,16 @@ func printStatus(status *api.Status) error {
 			}
 		}
 
+		// Connection
+		fmt.Println("\nConnection:")
+		fmt.Printf("  API URL: %s\n", conn.URL)
+		fmt.Printf("  TLS: %s\n", describeTLSMode(conn))
+		if conn.Proxy != "" {
+			fmt.Printf("  Proxy: %s\n", conn.Proxy)
+		} else {
+			fmt.Println("  Proxy: direct")
+		}
+
 		// Components
 		if len(status.Components) > 0 {
 			fmt.Println("\nComponents:")
@@ -123,6 +127,24 @@ func printStatus(status *api.Status) error {
 				fmt.Printf("  Session Clusters: %d\n", status.ResourceUsage.SessionClusters)
 			}
 		}
+		return nil
+	})
+}
+
+// describeTLSMode explains a TLS mode for the status table
+func describeTLSMode(conn api.ConnectionInfo) string {
+	switch conn.TLSMode {
+	case api.TLSModeNone:
+		return "none (plain HTTP)"
+	case api.TLSModeInsecure:
+		return "insecure (certificate verification disabled)"
+	case api.TLSModeCustomCA:
+		return fmt.Sprintf("custom CA (%s)", conn.CAFile)
+	case api.TLSModeMutual:
+		if conn.CAFile != "" {
+			return fmt.Sprintf("mutual TLS with client certificate, custom CA (%s)", conn.CAFile)
+		}
+		return "mutual TLS with client certificate"
 	}
-	return nil
+	return "system CA roots"
 }
@@ -2,8 +2,10 @@ package cmd
 
 import (
     "fmt"
+    "os"
     "time"
     "mcolomerc/vvp2cli/pkg/api"
+    "mcolomerc/vvp2cli/pkg/printer"
     "github.com/spf13/cobra"
 )
 
@@ -37,31 +39,25 @@ var usageReportCmd = &cobra.Command{
 				f = now.Add(-7 * 24 * time.Hour).Format(layout)
 			}
 		}
-		report, err := client.GetResourceUsageReport(f, t)
+		report, err := client.GetResourceUsageReportContext(cmd.Context(), f, t)
 		if err != nil {
 			return fmt.Errorf("failed to get resource usage report: %w", err)
 		}
-		format := GetConfig().GetOutputFormat()
-		switch format {
-		case "json":
-			// Parse CSV and convert to JSON
-			data, err := report.ParseCSV()
-			if err != nil {
-				return fmt.Errorf("failed to parse CSV data: %w", err)
-			}
-			return printJSON(data)
-		case "yaml":
-			// Parse CSV and convert to YAML
-			data, err := report.ParseCSV()
-			if err != nil {
-				return fmt.Errorf("failed to parse CSV data: %w", err)
-			}
-			return printYAML(data)
-		default:
+		format, err := printer.ParseFormat(outputFormat())
+		if err != nil {
+			return err
+		}
+		if format.IsTable() {
 			// For table/default, just print the CSV directly
 			fmt.Println(report.CSVData)
 			return nil
 		}
+		// Parse CSV and convert to the requested format
+		data, err := report.ParseCSV()
+		if err != nil {
+			return fmt.Errorf("failed to parse CSV data: %w", err)
+		}
+		return printer.Print(os.Stdout, format, data, printer.Options{})
 	},
 }
 
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"mcolomerc/vvp2cli/pkg/api"
+)
+
+// defaultWaitTimeout bounds how long --wait and wait commands poll
+const defaultWaitTimeout = 5 * time.Minute
+
+// parseWaitFor parses a --for condition of the form state=<STATE>
+func parseWaitFor(condition string) (string, error) {
+	key, value, ok := strings.Cut(condition, "=")
+	if !ok || key != "state" || value == "" {
+		return "", fmt.Errorf("invalid --for condition %q: expected state=<STATE>", condition)
+	}
+	return strings.ToUpper(value), nil
+}
+
+// waitOptions builds polling options from a --timeout value
+func waitOptions(timeout time.Duration) api.WaitOptions {
+	return api.WaitOptions{Timeout: timeout}
+}
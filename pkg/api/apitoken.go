@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// API token roles, from least to most privileged
+var APITokenRoles = []string{"viewer", "editor", "owner"}
+
+// APIToken is a namespace-scoped API token. Name is the resource name
+// (namespaces/{namespace}/apitokens/{name}); Secret is only returned when the
+// token is created.
+type APIToken struct {
+	Name       string    `json:"name" yaml:"name"`
+	Role       string    `json:"role,omitempty" yaml:"role,omitempty"`
+	Secret     string    `json:"secret,omitempty" yaml:"secret,omitempty"`
+	CreateTime time.Time `json:"createTime,omitempty" yaml:"createTime,omitempty"`
+}
+
+// APITokenList represents a list of API tokens
+type APITokenList struct {
+	APITokens []APIToken `json:"apiTokens" yaml:"apiTokens"`
+}
+
+// ShortName returns the token name without the namespace prefix
+func (t *APIToken) ShortName() string {
+	return t.Name[strings.LastIndex(t.Name, "/")+1:]
+}
+
+// apiTokenResourceName returns the full resource name of a token
+func apiTokenResourceName(namespace, name string) string {
+	if strings.HasPrefix(name, "namespaces/") {
+		return name
+	}
+	return fmt.Sprintf("namespaces/%s/apitokens/%s", namespace, name)
+}
+
+// ListAPITokens lists all API tokens in a namespace
+func (c *Client) ListAPITokens(namespace string) (*APITokenList, error) {
+	return c.ListAPITokensContext(context.Background(), namespace)
+}
+
+// ListAPITokensContext is like ListAPITokens but carries ctx through the request
+func (c *Client) ListAPITokensContext(ctx context.Context, namespace string) (*APITokenList, error) {
+	var result APITokenList
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetResult(&result).
+		Get(fmt.Sprintf("/apitokens/v1/namespaces/%s/apitokens", namespace))
+
+	if err := handleResponse(resp, err); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetAPIToken gets an API token by name
+func (c *Client) GetAPIToken(namespace, name string) (*APIToken, error) {
+	return c.GetAPITokenContext(context.Background(), namespace, name)
+}
+
+// GetAPITokenContext is like GetAPIToken but carries ctx through the request
+func (c *Client) GetAPITokenContext(ctx context.Context, namespace, name string) (*APIToken, error) {
+	var result APIToken
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetResult(&result).
+		Get(fmt.Sprintf("/apitokens/v1/namespaces/%s/apitokens/%s", namespace, name))
+
+	if err := handleResponse(resp, err); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateAPIToken creates an API token. The returned token carries the secret,
+// which cannot be retrieved again.
+func (c *Client) CreateAPIToken(namespace string, token *APIToken) (*APIToken, error) {
+	return c.CreateAPITokenContext(context.Background(), namespace, token)
+}
+
+// CreateAPITokenContext is like CreateAPIToken but carries ctx through the request
+func (c *Client) CreateAPITokenContext(ctx context.Context, namespace string, token *APIToken) (*APIToken, error) {
+	body := *token
+	body.Name = apiTokenResourceName(namespace, token.Name)
+
+	var result APIToken
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetBody(&body).
+		SetResult(&result).
+		Post(fmt.Sprintf("/apitokens/v1/namespaces/%s/apitokens", namespace))
+
+	if err := handleResponse(resp, err); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DeleteAPIToken deletes an API token
+func (c *Client) DeleteAPIToken(namespace, name string) error {
+	return c.DeleteAPITokenContext(context.Background(), namespace, name)
+}
+
+// DeleteAPITokenContext is like DeleteAPIToken but carries ctx through the request
+func (c *Client) DeleteAPITokenContext(ctx context.Context, namespace, name string) error {
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		Delete(fmt.Sprintf("/apitokens/v1/namespaces/%s/apitokens/%s", namespace, name))
+
+	return handleResponse(resp, err)
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestCreateAPIToken(t *testing.T) {
+	var got APIToken
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/apitokens/v1/namespaces/default/apitokens" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		got.Secret = "s3cr3t"
+		writeJSON(w, got)
+	})
+
+	token, err := client.CreateAPIToken("default", &APIToken{Name: "ci", Role: "editor"})
+	if err != nil {
+		t.Fatalf("Failed to create API token: %v", err)
+	}
+	if got.Name != "namespaces/default/apitokens/ci" {
+		t.Errorf("Expected full resource name in request, got '%s'", got.Name)
+	}
+	if got.Role != "editor" {
+		t.Errorf("Expected role 'editor', got '%s'", got.Role)
+	}
+	if token.Secret != "s3cr3t" || token.ShortName() != "ci" {
+		t.Errorf("Unexpected token: %+v", token)
+	}
+}
+
+func TestListAPITokens(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apitokens/v1/namespaces/default/apitokens" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"apiTokens": [
+			{"name": "namespaces/default/apitokens/ci", "role": "editor", "createTime": "2024-05-01T12:00:00Z"},
+			{"name": "namespaces/default/apitokens/dashboards", "role": "viewer"}
+		]}`))
+	})
+
+	list, err := client.ListAPITokens("default")
+	if err != nil {
+		t.Fatalf("Failed to list API tokens: %v", err)
+	}
+	if len(list.APITokens) != 2 {
+		t.Fatalf("Expected 2 tokens, got %d", len(list.APITokens))
+	}
+	if list.APITokens[0].ShortName() != "ci" || list.APITokens[0].CreateTime.IsZero() {
+		t.Errorf("Unexpected first token: %+v", list.APITokens[0])
+	}
+	if list.APITokens[1].Role != "viewer" {
+		t.Errorf("Expected role 'viewer', got '%s'", list.APITokens[1].Role)
+	}
+}
+
+func TestDeleteAPIToken(t *testing.T) {
+	var path string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.Method + " " + r.URL.Path
+		writeJSON(w, map[string]string{})
+	})
+
+	if err := client.DeleteAPIToken("default", "ci"); err != nil {
+		t.Fatalf("Failed to delete API token: %v", err)
+	}
+	if path != "DELETE /apitokens/v1/namespaces/default/apitokens/ci" {
+		t.Errorf("Unexpected request %s", path)
+	}
+}
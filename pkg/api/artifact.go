@@ -0,0 +1,181 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// ArtifactFile is a file stored in the artifact storage of a namespace. Its
+// URI can be used as the jarUri of a deployment artifact.
+type ArtifactFile struct {
+	Filename   string    `json:"filename" yaml:"filename"`
+	URI        string    `json:"uri,omitempty" yaml:"uri,omitempty"`
+	Size       int64     `json:"size,omitempty" yaml:"size,omitempty"`
+	CreateTime time.Time `json:"createTime,omitempty" yaml:"createTime,omitempty"`
+	ModifyTime time.Time `json:"modifyTime,omitempty" yaml:"modifyTime,omitempty"`
+}
+
+// ArtifactFileList represents a list of stored artifacts
+type ArtifactFileList struct {
+	Artifacts []ArtifactFile `json:"artifacts" yaml:"artifacts"`
+}
+
+// artifactResponse wraps a single artifact returned by the API
+type artifactResponse struct {
+	Artifact ArtifactFile `json:"artifact"`
+}
+
+// ListArtifacts lists the artifacts stored in a namespace
+func (c *Client) ListArtifacts(namespace string) (*ArtifactFileList, error) {
+	return c.ListArtifactsContext(context.Background(), namespace)
+}
+
+// ListArtifactsContext is like ListArtifacts but carries ctx through the request
+func (c *Client) ListArtifactsContext(ctx context.Context, namespace string) (*ArtifactFileList, error) {
+	var result ArtifactFileList
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetResult(&result).
+		Get(fmt.Sprintf("/artifacts/v1/namespaces/%s/artifacts:list", namespace))
+
+	if err := handleResponse(resp, err); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetArtifact gets the metadata of a stored artifact
+func (c *Client) GetArtifact(namespace, filename string) (*ArtifactFile, error) {
+	return c.GetArtifactContext(context.Background(), namespace, filename)
+}
+
+// GetArtifactContext is like GetArtifact but carries ctx through the request
+func (c *Client) GetArtifactContext(ctx context.Context, namespace, filename string) (*ArtifactFile, error) {
+	var result artifactResponse
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetQueryParam("filename", filename).
+		SetResult(&result).
+		Get(fmt.Sprintf("/artifacts/v1/namespaces/%s/artifacts:getMetadata", namespace))
+
+	if err := handleResponse(resp, err); err != nil {
+		return nil, err
+	}
+
+	return &result.Artifact, nil
+}
+
+// UploadArtifact uploads content as a multipart file named filename
+func (c *Client) UploadArtifact(namespace, filename string, content io.Reader) (*ArtifactFile, error) {
+	return c.UploadArtifactContext(context.Background(), namespace, filename, content, nil)
+}
+
+// UploadArtifactContext is like UploadArtifact but carries ctx through the
+// request. The multipart body is streamed from content while it is sent, and
+// the body bytes are written to progress, if not nil, as the request reads
+// them. The upload is bounded by ctx only, not by the API request timeout.
+// It is never retried, since content has been consumed by the first attempt.
+func (c *Client) UploadArtifactContext(ctx context.Context, namespace, filename string, content io.Reader, progress io.Writer) (*ArtifactFile, error) {
+	body, pipe := io.Pipe()
+	// Unblocks the writer if the request ends before reading the whole body
+	defer body.Close()
+
+	form := multipart.NewWriter(pipe)
+	go func() {
+		pipe.CloseWithError(writeMultipartFile(form, "file", filename, content))
+	}()
+
+	var reader io.Reader = body
+	if progress != nil {
+		reader = io.TeeReader(body, progress)
+	}
+
+	var result artifactResponse
+	resp, err := c.transferClient.R().
+		SetContext(withStreamedBody(ctx, reader)).
+		SetHeader("Content-Type", form.FormDataContentType()).
+		SetResult(&result).
+		Post(fmt.Sprintf("/artifacts/v1/namespaces/%s/artifacts:upload", namespace))
+
+	if err := handleResponse(resp, err); err != nil {
+		return nil, err
+	}
+
+	return &result.Artifact, nil
+}
+
+// quoteEscaper escapes a multipart file name like mime/multipart does
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// writeMultipartFile writes content as the only part of form and closes it
+func writeMultipartFile(form *multipart.Writer, field, filename string, content io.Reader) error {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+		quoteEscaper.Replace(field), quoteEscaper.Replace(filename)))
+	header.Set("Content-Type", "application/java-archive")
+
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, content); err != nil {
+		return err
+	}
+	return form.Close()
+}
+
+// DownloadArtifact streams a stored artifact to w and returns the number of
+// bytes written
+func (c *Client) DownloadArtifact(namespace, filename string, w io.Writer) (int64, error) {
+	return c.DownloadArtifactContext(context.Background(), namespace, filename, w)
+}
+
+// DownloadArtifactContext is like DownloadArtifact but carries ctx through the
+// request. The download is bounded by ctx only, not by the API request timeout.
+func (c *Client) DownloadArtifactContext(ctx context.Context, namespace, filename string, w io.Writer) (int64, error) {
+	resp, err := c.transferClient.R().
+		SetContext(ctx).
+		SetQueryParam("filename", filename).
+		SetDoNotParseResponse(true).
+		Get(fmt.Sprintf("/artifacts/v1/namespaces/%s/artifacts:download", namespace))
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	body := resp.RawBody()
+	defer body.Close()
+
+	if resp.IsError() {
+		// The body was not read by resty, so load it for the error details
+		data, _ := io.ReadAll(io.LimitReader(body, 64*1024))
+		resp.SetBody(bytes.TrimSpace(data))
+		return 0, newAPIError(resp)
+	}
+
+	n, err := io.Copy(w, body)
+	if err != nil {
+		return n, fmt.Errorf("failed to download artifact: %w", err)
+	}
+	return n, nil
+}
+
+// DeleteArtifact deletes a stored artifact
+func (c *Client) DeleteArtifact(namespace, filename string) error {
+	return c.DeleteArtifactContext(context.Background(), namespace, filename)
+}
+
+// DeleteArtifactContext is like DeleteArtifact but carries ctx through the request
+func (c *Client) DeleteArtifactContext(ctx context.Context, namespace, filename string) error {
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetQueryParam("filename", filename).
+		Delete(fmt.Sprintf("/artifacts/v1/namespaces/%s/artifacts:delete", namespace))
+
+	return handleResponse(resp, err)
+}
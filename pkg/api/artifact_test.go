@@ -0,0 +1,173 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUploadArtifact(t *testing.T) {
+	var filename, content string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/artifacts/v1/namespaces/default/artifacts:upload" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("Expected multipart file: %v", err)
+		}
+		data, _ := io.ReadAll(file)
+		filename, content = header.Filename, string(data)
+		writeJSON(w, artifactResponse{Artifact: ArtifactFile{
+			Filename: header.Filename,
+			URI:      "s3://vvp/artifacts/namespaces/default/" + header.Filename,
+		}})
+	})
+
+	artifact, err := client.UploadArtifact("default", "job.jar", strings.NewReader("jar bytes"))
+	if err != nil {
+		t.Fatalf("Failed to upload artifact: %v", err)
+	}
+	if filename != "job.jar" || content != "jar bytes" {
+		t.Errorf("Unexpected upload %s: %q", filename, content)
+	}
+	if artifact.URI != "s3://vvp/artifacts/namespaces/default/job.jar" {
+		t.Errorf("Unexpected URI '%s'", artifact.URI)
+	}
+}
+
+// blockingReader returns EOF only once release is closed
+type blockingReader struct {
+	release <-chan struct{}
+}
+
+func (r blockingReader) Read(p []byte) (int, error) {
+	select {
+	case <-r.release:
+		return 0, io.EOF
+	case <-time.After(5 * time.Second):
+		return 0, errors.New("upload body was not sent before the content was fully read")
+	}
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n atomic.Int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+func (c *countingReader) Write(p []byte) (int, error) {
+	c.n.Add(int64(len(p)))
+	return len(p), nil
+}
+
+func TestUploadArtifactStreams(t *testing.T) {
+	received := make(chan struct{})
+	body := &countingReader{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body.r = r.Body
+		r.Body = io.NopCloser(body)
+		reader, err := r.MultipartReader()
+		if err != nil {
+			t.Fatalf("Expected multipart body: %v", err)
+		}
+		part, err := reader.NextPart()
+		if err != nil {
+			t.Fatalf("Expected multipart file: %v", err)
+		}
+
+		// The first bytes arrive while the content is still being read
+		first := make([]byte, len("first chunk"))
+		if _, err := io.ReadFull(part, first); err != nil || string(first) != "first chunk" {
+			t.Errorf("Expected the first chunk, got %q (%v)", first, err)
+		}
+		close(received)
+		io.Copy(io.Discard, r.Body)
+		writeJSON(w, artifactResponse{Artifact: ArtifactFile{Filename: part.FileName()}})
+	})
+
+	progress := &countingReader{}
+	content := io.MultiReader(strings.NewReader("first chunk"), blockingReader{release: received})
+	if _, err := client.UploadArtifactContext(context.Background(), "default", "job.jar", content, progress); err != nil {
+		t.Fatalf("Failed to upload artifact: %v", err)
+	}
+	if sent, got := progress.n.Load(), body.n.Load(); sent != got {
+		t.Errorf("Expected progress to count the %d body bytes sent, got %d", got, sent)
+	}
+}
+
+func TestListArtifacts(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/artifacts/v1/namespaces/default/artifacts:list" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"artifacts": [
+			{"filename": "a.jar", "uri": "s3://vvp/a.jar", "createTime": "2024-05-01T12:00:00Z"},
+			{"filename": "b.jar", "uri": "s3://vvp/b.jar"}
+		]}`))
+	})
+
+	list, err := client.ListArtifacts("default")
+	if err != nil {
+		t.Fatalf("Failed to list artifacts: %v", err)
+	}
+	if len(list.Artifacts) != 2 || list.Artifacts[0].Filename != "a.jar" || list.Artifacts[0].CreateTime.IsZero() {
+		t.Errorf("Unexpected artifacts: %+v", list.Artifacts)
+	}
+}
+
+func TestDownloadArtifact(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("filename") != "job.jar" {
+			w.WriteHeader(http.StatusNotFound)
+			writeJSON(w, map[string]string{"reason": "NotFound", "message": "artifact not found"})
+			return
+		}
+		w.Write([]byte("jar bytes"))
+	})
+
+	var buf bytes.Buffer
+	n, err := client.DownloadArtifact("default", "job.jar", &buf)
+	if err != nil {
+		t.Fatalf("Failed to download artifact: %v", err)
+	}
+	if n != 9 || buf.String() != "jar bytes" {
+		t.Errorf("Unexpected download (%d bytes): %q", n, buf.String())
+	}
+
+	_, err = client.DownloadArtifact("default", "missing.jar", &buf)
+	if !IsNotFound(err) {
+		t.Fatalf("Expected not found error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "artifact not found") {
+		t.Errorf("Expected error message from the response body, got %v", err)
+	}
+}
+
+func TestDeleteArtifact(t *testing.T) {
+	var request string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		request = r.Method + " " + r.URL.Path + "?" + r.URL.RawQuery
+		writeJSON(w, map[string]string{})
+	})
+
+	if err := client.DeleteArtifact("default", "job.jar"); err != nil {
+		t.Fatalf("Failed to delete artifact: %v", err)
+	}
+	if request != "DELETE /artifacts/v1/namespaces/default/artifacts:delete?filename=job.jar" {
+		t.Errorf("Unexpected request %s", request)
+	}
+}
@@ -1,10 +1,13 @@
 package api
 
 import (
-	"crypto/tls"
+	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"time"
 
+	"mcolomerc/vvp2cli/pkg/auth"
 	"mcolomerc/vvp2cli/pkg/config"
 
 	"github.com/go-resty/resty/v2"
@@ -13,50 +16,113 @@ import (
 // Client is the VVP API client
 type Client struct {
 	httpClient *resty.Client
-	baseURL    string
-	token      string
+	// transferClient has no overall timeout, for artifact uploads and
+	// downloads that may take longer than regular API calls
+	transferClient *resty.Client
+	baseURL        string
+	tokens         auth.TokenSource
 }
 
+// requestTimeout bounds regular API requests
+const requestTimeout = 30 * time.Second
+
 // NewClient creates a new VVP API client
 func NewClient(cfg *config.Config) (*Client, error) {
 	if cfg == nil {
 		return nil, fmt.Errorf("config cannot be nil")
 	}
 
+	// Resolve the bearer token per request, so helpers can refresh it. OIDC
+	// token requests use the same TLS and proxy settings as the API.
+	oidcClient, err := NewHTTPClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := auth.NewTokenSource(cfg, oidcClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure credentials: %w", err)
+	}
+
+	httpClient, err := newRestyClient(cfg, tokens, requestTimeout)
+	if err != nil {
+		return nil, err
+	}
+	transferClient, err := newRestyClient(cfg, tokens, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		httpClient:     httpClient,
+		transferClient: transferClient,
+		baseURL:        cfg.GetAPIURL(),
+		tokens:         tokens,
+	}, nil
+}
+
+// newRestyClient returns an HTTP client for the API in cfg. A zero timeout
+// leaves requests bounded only by their context.
+func newRestyClient(cfg *config.Config, tokens auth.TokenSource, timeout time.Duration) (*resty.Client, error) {
 	httpClient := resty.New()
-	httpClient.SetTimeout(30 * time.Second)
+	httpClient.SetTimeout(timeout)
 	httpClient.SetBaseURL(cfg.GetAPIURL())
 
-	// Set token if provided
-	if cfg.GetToken() != "" {
-		httpClient.SetHeader("Authorization", fmt.Sprintf("Bearer %s", cfg.GetToken()))
+	if tokens != nil {
+		httpClient.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+			token, err := tokens.Token(req.Context())
+			if err != nil {
+				return fmt.Errorf("failed to get API token: %w", err)
+			}
+			if token != "" {
+				req.SetAuthToken(token)
+			}
+			return nil
+		})
 	}
 
-	// Skip TLS verification if configured
-	if cfg.IsInsecure() {
-		httpClient.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true})
+	// TLS (custom CA, client certificates or skipped verification) and proxy
+	transport, err := httpClient.Transport()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure transport: %w", err)
+	}
+	if err := configureTransport(transport, cfg); err != nil {
+		return nil, err
 	}
 
-	return &Client{
-		httpClient: httpClient,
-		baseURL:    cfg.GetAPIURL(),
-		token:      cfg.GetToken(),
-	}, nil
+	configureRetries(httpClient, cfg)
+	httpClient.SetPreRequestHook(setStreamedBody)
+
+	return httpClient, nil
 }
 
-// SetDebug enables debug mode for the HTTP client
-func (c *Client) SetDebug(debug bool) {
-	c.httpClient.SetDebug(debug)
+// streamedBodyKey carries a request body that is sent while it is read
+type streamedBodyKey struct{}
+
+// withStreamedBody returns a context whose request sends body as it is read,
+// e.g. a large upload. resty reads an io.Reader body into memory so that it
+// can be sent again, so such requests set no body of their own and are never
+// retried.
+func withStreamedBody(ctx context.Context, body io.Reader) context.Context {
+	return context.WithValue(withoutRetries(ctx), streamedBodyKey{}, body)
 }
 
-// APIError represents an API error response
-type APIError struct {
-	StatusCode int
-	Message    string
+// setStreamedBody attaches the body of a withStreamedBody context to the HTTP
+// request about to be sent
+func setStreamedBody(_ *resty.Client, req *http.Request) error {
+	body, ok := req.Context().Value(streamedBodyKey{}).(io.Reader)
+	if !ok {
+		return nil
+	}
+	req.Body = io.NopCloser(body)
+	req.GetBody = nil
+	req.ContentLength = -1
+	return nil
 }
 
-func (e *APIError) Error() string {
-	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Message)
+// SetDebug enables debug mode for the HTTP client
+func (c *Client) SetDebug(debug bool) {
+	c.httpClient.SetDebug(debug)
+	c.transferClient.SetDebug(debug)
 }
 
 // handleResponse checks the response and returns an error if needed
@@ -66,10 +132,7 @@ func handleResponse(resp *resty.Response, err error) error {
 	}
 
 	if resp.IsError() {
-		return &APIError{
-			StatusCode: resp.StatusCode(),
-			Message:    string(resp.Body()),
-		}
+		return newAPIError(resp)
 	}
 
 	return nil
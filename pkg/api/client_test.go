@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"mcolomerc/vvp2cli/pkg/config"
+)
+
+func TestContextCancelsRequest(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.ListNamespacesContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected request to be aborted promptly, took %v", elapsed)
+	}
+}
+
+func TestWaitStopsOnCancel(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, DeploymentWithInfo{Deployment: Deployment{Status: &DeploymentStatus{State: "TRANSITIONING"}}})
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	opts := WaitOptions{Interval: 10 * time.Millisecond}
+	_, err := client.WaitForDeploymentStateContext(ctx, "default", "job", "RUNNING", opts)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestTokenResolvedPerRequest(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Authorization"))
+		writeJSON(w, NamespaceList{})
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	os.WriteFile(tokenFile, []byte("first"), 0600)
+
+	client, err := NewClient(&config.Config{API: config.APIConfig{URL: server.URL, TokenFile: tokenFile}})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	client.ListNamespaces()
+	os.WriteFile(tokenFile, []byte("second"), 0600)
+	client.ListNamespaces()
+
+	if len(got) != 2 || got[0] != "Bearer first" || got[1] != "Bearer second" {
+		t.Errorf("Expected rotated bearer tokens, got %v", got)
+	}
+}
+
+func TestTokenSourceErrorFailsRequest(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	missing := filepath.Join(t.TempDir(), "missing")
+	client, err := NewClient(&config.Config{API: config.APIConfig{URL: server.URL, TokenFile: missing}})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := client.ListNamespaces(); err == nil {
+		t.Error("Expected error when the token cannot be read")
+	}
+	if calls != 0 {
+		t.Errorf("Expected no request without a token, got %d", calls)
+	}
+}
@@ -1,8 +1,11 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"time"
+
+	"mcolomerc/vvp2cli/pkg/labels"
 )
 
 // Deployment represents a VVP deployment
@@ -29,6 +32,7 @@ type DeploymentSpec struct {
 	UpgradeStrategy      UpgradeStrategy `json:"upgradeStrategy,omitempty" yaml:"upgradeStrategy,omitempty"`
 	RestoreStrategy      RestoreStrategy `json:"restoreStrategy,omitempty" yaml:"restoreStrategy,omitempty"`
 	DeploymentTargetName string          `json:"deploymentTargetName,omitempty" yaml:"deploymentTargetName,omitempty"`
+	SessionClusterName   string          `json:"sessionClusterName,omitempty" yaml:"sessionClusterName,omitempty"`
 	Template             Template        `json:"template" yaml:"template"`
 	MaxSavepointAge      string          `json:"maxSavepointCreationTime,omitempty" yaml:"maxSavepointCreationTime,omitempty"`
 	MaxJobCreationTime   string          `json:"maxJobCreationTime,omitempty" yaml:"maxJobCreationTime,omitempty"`
@@ -258,8 +262,29 @@ type DeploymentWithInfo struct {
 
 // ListDeployments lists all deployments in a namespace
 func (c *Client) ListDeployments(namespace string) (*DeploymentList, error) {
+	return c.ListDeploymentsContext(context.Background(), namespace)
+}
+
+// ListDeploymentsContext is like ListDeployments but carries ctx through the request
+func (c *Client) ListDeploymentsContext(ctx context.Context, namespace string) (*DeploymentList, error) {
+	return c.ListDeploymentsMatchingContext(ctx, namespace, nil)
+}
+
+// ListDeploymentsMatching lists the deployments in a namespace whose labels
+// match selector. The selector is passed to the server as labelSelector.
+func (c *Client) ListDeploymentsMatching(namespace string, selector labels.Selector) (*DeploymentList, error) {
+	return c.ListDeploymentsMatchingContext(context.Background(), namespace, selector)
+}
+
+// ListDeploymentsMatchingContext is like ListDeploymentsMatching but carries ctx through the request
+func (c *Client) ListDeploymentsMatchingContext(ctx context.Context, namespace string, selector labels.Selector) (*DeploymentList, error) {
+	req := c.httpClient.R().SetContext(ctx)
+	if !selector.Empty() {
+		req.SetQueryParam("labelSelector", selector.String())
+	}
+
 	var result DeploymentList
-	resp, err := c.httpClient.R().
+	resp, err := req.
 		SetResult(&result).
 		Get(fmt.Sprintf("/api/v1/namespaces/%s/deployments/with-cr", namespace))
 
@@ -267,13 +292,30 @@ func (c *Client) ListDeployments(namespace string) (*DeploymentList, error) {
 		return nil, err
 	}
 
+	// Filter client-side as well, in case the server ignores the selector
+	if !selector.Empty() {
+		items := make([]DeploymentWithInfo, 0, len(result.Items))
+		for _, item := range result.Items {
+			if selector.Matches(item.Deployment.Metadata.Labels) {
+				items = append(items, item)
+			}
+		}
+		result.Items = items
+	}
+
 	return &result, nil
 }
 
 // GetDeployment gets a deployment by name
 func (c *Client) GetDeployment(namespace, name string) (*Deployment, error) {
+	return c.GetDeploymentContext(context.Background(), namespace, name)
+}
+
+// GetDeploymentContext is like GetDeployment but carries ctx through the request
+func (c *Client) GetDeploymentContext(ctx context.Context, namespace, name string) (*Deployment, error) {
 	var wrapper DeploymentWithInfo
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetResult(&wrapper).
 		Get(fmt.Sprintf("/api/v1/namespaces/%s/deployments/with-cr/%s", namespace, name))
 
@@ -286,8 +328,14 @@ func (c *Client) GetDeployment(namespace, name string) (*Deployment, error) {
 
 // CreateDeployment creates a new deployment
 func (c *Client) CreateDeployment(namespace string, deployment *Deployment) (*Deployment, error) {
+	return c.CreateDeploymentContext(context.Background(), namespace, deployment)
+}
+
+// CreateDeploymentContext is like CreateDeployment but carries ctx through the request
+func (c *Client) CreateDeploymentContext(ctx context.Context, namespace string, deployment *Deployment) (*Deployment, error) {
 	var result Deployment
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetBody(deployment).
 		SetResult(&result).
 		Post(fmt.Sprintf("/api/v1/namespaces/%s/deployments", namespace))
@@ -301,9 +349,15 @@ func (c *Client) CreateDeployment(namespace string, deployment *Deployment) (*De
 
 // UpdateDeployment updates an existing deployment
 func (c *Client) UpdateDeployment(namespace, name string, deployment *Deployment) (*Deployment, error) {
+	return c.UpdateDeploymentContext(context.Background(), namespace, name, deployment)
+}
+
+// UpdateDeploymentContext is like UpdateDeployment but carries ctx through the request
+func (c *Client) UpdateDeploymentContext(ctx context.Context, namespace, name string, deployment *Deployment) (*Deployment, error) {
 	// NOTE: remove debug logging; could be re-enabled with a verbose flag later
 	var result Deployment
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetBody(deployment).
 		SetResult(&result).
 		Put(fmt.Sprintf("/api/v1/namespaces/%s/deployments/%s", namespace, name))
@@ -317,7 +371,13 @@ func (c *Client) UpdateDeployment(namespace, name string, deployment *Deployment
 
 // DeleteDeployment deletes a deployment
 func (c *Client) DeleteDeployment(namespace, name string) error {
+	return c.DeleteDeploymentContext(context.Background(), namespace, name)
+}
+
+// DeleteDeploymentContext is like DeleteDeployment but carries ctx through the request
+func (c *Client) DeleteDeploymentContext(ctx context.Context, namespace, name string) error {
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		Delete(fmt.Sprintf("/api/v1/namespaces/%s/deployments/%s", namespace, name))
 
 	return handleResponse(resp, err)
@@ -325,6 +385,11 @@ func (c *Client) DeleteDeployment(namespace, name string) error {
 
 // UpdateDeploymentState updates the state of a deployment
 func (c *Client) UpdateDeploymentState(namespace, name, state string) (*Deployment, error) {
+	return c.UpdateDeploymentStateContext(context.Background(), namespace, name, state)
+}
+
+// UpdateDeploymentStateContext is like UpdateDeploymentState but carries ctx through the request
+func (c *Client) UpdateDeploymentStateContext(ctx context.Context, namespace, name, state string) (*Deployment, error) {
 	patch := map[string]interface{}{
 		"spec": map[string]interface{}{
 			"state": state,
@@ -333,6 +398,7 @@ func (c *Client) UpdateDeploymentState(namespace, name, state string) (*Deployme
 
 	var result Deployment
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetBody(patch).
 		SetResult(&result).
 		Patch(fmt.Sprintf("/api/v1/namespaces/%s/deployments/%s", namespace, name))
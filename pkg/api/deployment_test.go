@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"mcolomerc/vvp2cli/pkg/labels"
+)
+
+func TestListDeploymentsMatching(t *testing.T) {
+	var gotSelector string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotSelector = r.URL.Query().Get("labelSelector")
+		// Respond like a server that ignores labelSelector
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [
+			{"deployment": {"metadata": {"name": "orders", "labels": {"team": "data-platform", "type": "streaming"}}}},
+			{"deployment": {"metadata": {"name": "scratch", "labels": {"team": "data-platform", "type": "adhoc"}}}},
+			{"deployment": {"metadata": {"name": "billing", "labels": {"team": "finance"}}}}
+		]}`))
+	})
+
+	selector, err := labels.Parse("team=data-platform,type!=adhoc")
+	if err != nil {
+		t.Fatalf("Failed to parse selector: %v", err)
+	}
+	deployments, err := client.ListDeploymentsMatching("default", selector)
+	if err != nil {
+		t.Fatalf("Failed to list deployments: %v", err)
+	}
+	if gotSelector != "team=data-platform,type!=adhoc" {
+		t.Errorf("Expected labelSelector to be sent, got %q", gotSelector)
+	}
+	if len(deployments.Items) != 1 || deployments.Items[0].Deployment.Metadata.Name != "orders" {
+		t.Errorf("Expected only orders, got %+v", deployments.Items)
+	}
+
+	// Without a selector, nothing is filtered or sent
+	deployments, err = client.ListDeployments("default")
+	if err != nil {
+		t.Fatalf("Failed to list deployments: %v", err)
+	}
+	if gotSelector != "" || len(deployments.Items) != 3 {
+		t.Errorf("Expected all 3 deployments without labelSelector, got %d (labelSelector %q)", len(deployments.Items), gotSelector)
+	}
+}
@@ -1,28 +1,29 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
 
 // DeploymentDefaults represents the namespace-level defaults for deployments
 type DeploymentDefaults struct {
-	APIVersion string                     `json:"apiVersion,omitempty"`
-	Kind       string                     `json:"kind,omitempty"`
-	Metadata   DeploymentDefaultsMetadata `json:"metadata"`
-	Spec       DeploymentSpec             `json:"spec"`
+	APIVersion string                     `json:"apiVersion,omitempty" yaml:"apiVersion,omitempty"`
+	Kind       string                     `json:"kind,omitempty" yaml:"kind,omitempty"`
+	Metadata   DeploymentDefaultsMetadata `json:"metadata" yaml:"metadata"`
+	Spec       DeploymentSpec             `json:"spec" yaml:"spec"`
 }
 
 // DeploymentDefaultsMetadata holds metadata for deployment defaults
 type DeploymentDefaultsMetadata struct {
-	ID              string            `json:"id,omitempty"`
-	Name            string            `json:"name,omitempty"`
-	Namespace       string            `json:"namespace,omitempty"`
-	Labels          map[string]string `json:"labels,omitempty"`
-	Annotations     map[string]string `json:"annotations,omitempty"`
-	CreatedAt       time.Time         `json:"createdAt,omitempty"`
-	ModifiedAt      time.Time         `json:"modifiedAt,omitempty"`
-	ResourceVersion int32             `json:"resourceVersion,omitempty"`
+	ID              string            `json:"id,omitempty" yaml:"id,omitempty"`
+	Name            string            `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespace       string            `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	CreatedAt       time.Time         `json:"createdAt,omitempty" yaml:"createdAt,omitempty"`
+	ModifiedAt      time.Time         `json:"modifiedAt,omitempty" yaml:"modifiedAt,omitempty"`
+	ResourceVersion int32             `json:"resourceVersion,omitempty" yaml:"resourceVersion,omitempty"`
 }
 
 // SecretValue represents a secret value resource used by some endpoints
@@ -53,8 +54,14 @@ type SecretValueSpec struct {
 
 // GetDeploymentDefaults retrieves the deployment defaults for a namespace
 func (c *Client) GetDeploymentDefaults(namespace string) (*DeploymentDefaults, error) {
+	return c.GetDeploymentDefaultsContext(context.Background(), namespace)
+}
+
+// GetDeploymentDefaultsContext is like GetDeploymentDefaults but carries ctx through the request
+func (c *Client) GetDeploymentDefaultsContext(ctx context.Context, namespace string) (*DeploymentDefaults, error) {
 	var result DeploymentDefaults
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetResult(&result).
 		Get(fmt.Sprintf("/api/v1/namespaces/%s/deployment-defaults", namespace))
 
@@ -67,8 +74,14 @@ func (c *Client) GetDeploymentDefaults(namespace string) (*DeploymentDefaults, e
 
 // ReplaceDeploymentDefaults replaces the deployment defaults for a namespace
 func (c *Client) ReplaceDeploymentDefaults(namespace string, defaults *DeploymentDefaults) (*DeploymentDefaults, error) {
+	return c.ReplaceDeploymentDefaultsContext(context.Background(), namespace, defaults)
+}
+
+// ReplaceDeploymentDefaultsContext is like ReplaceDeploymentDefaults but carries ctx through the request
+func (c *Client) ReplaceDeploymentDefaultsContext(ctx context.Context, namespace string, defaults *DeploymentDefaults) (*DeploymentDefaults, error) {
 	var result DeploymentDefaults
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetBody(defaults).
 		SetResult(&result).
 		Put(fmt.Sprintf("/api/v1/namespaces/%s/deployment-defaults", namespace))
@@ -83,8 +96,14 @@ func (c *Client) ReplaceDeploymentDefaults(namespace string, defaults *Deploymen
 // UpdateDeploymentDefaults updates the deployment defaults via PATCH
 // According to the Application Manager spec, this endpoint accepts a SecretValue body.
 func (c *Client) UpdateDeploymentDefaults(namespace string, secret *SecretValue) (*DeploymentDefaults, error) {
+	return c.UpdateDeploymentDefaultsContext(context.Background(), namespace, secret)
+}
+
+// UpdateDeploymentDefaultsContext is like UpdateDeploymentDefaults but carries ctx through the request
+func (c *Client) UpdateDeploymentDefaultsContext(ctx context.Context, namespace string, secret *SecretValue) (*DeploymentDefaults, error) {
 	var result DeploymentDefaults
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetBody(secret).
 		SetResult(&result).
 		Patch(fmt.Sprintf("/api/v1/namespaces/%s/deployment-defaults", namespace))
@@ -1,52 +1,59 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
 
 // DeploymentTargetResource represents a VVP deployment target
 type DeploymentTargetResource struct {
-	Metadata DeploymentTargetMetadata `json:"metadata"`
-	Spec     DeploymentTargetSpec     `json:"spec"`
-	Status   DeploymentTargetStatus   `json:"status,omitempty"`
+	Metadata DeploymentTargetMetadata `json:"metadata" yaml:"metadata"`
+	Spec     DeploymentTargetSpec     `json:"spec" yaml:"spec"`
+	Status   DeploymentTargetStatus   `json:"status,omitempty" yaml:"status,omitempty"`
 }
 
 // DeploymentTargetMetadata holds deployment target metadata
 type DeploymentTargetMetadata struct {
-	ID          string            `json:"id,omitempty"`
-	Name        string            `json:"name"`
-	Namespace   string            `json:"namespace"`
-	Labels      map[string]string `json:"labels,omitempty"`
-	Annotations map[string]string `json:"annotations,omitempty"`
-	CreatedAt   time.Time         `json:"createdAt,omitempty"`
-	ModifiedAt  time.Time         `json:"modifiedAt,omitempty"`
+	ID          string            `json:"id,omitempty" yaml:"id,omitempty"`
+	Name        string            `json:"name" yaml:"name"`
+	Namespace   string            `json:"namespace" yaml:"namespace"`
+	Labels      map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	CreatedAt   time.Time         `json:"createdAt,omitempty" yaml:"createdAt,omitempty"`
+	ModifiedAt  time.Time         `json:"modifiedAt,omitempty" yaml:"modifiedAt,omitempty"`
 }
 
 // DeploymentTargetSpec holds deployment target specification
 type DeploymentTargetSpec struct {
-	Kubernetes KubernetesTarget `json:"kubernetes,omitempty"`
+	Kubernetes KubernetesTarget `json:"kubernetes,omitempty" yaml:"kubernetes,omitempty"`
 }
 
 // KubernetesTarget defines Kubernetes-specific settings
 type KubernetesTarget struct {
-	Namespace string `json:"namespace,omitempty"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 }
 
 // DeploymentTargetStatus holds deployment target status
 type DeploymentTargetStatus struct {
-	State string `json:"state,omitempty"`
+	State string `json:"state,omitempty" yaml:"state,omitempty"`
 }
 
 // DeploymentTargetList represents a list of deployment targets
 type DeploymentTargetList struct {
-	Items []DeploymentTargetResource `json:"items"`
+	Items []DeploymentTargetResource `json:"items" yaml:"items"`
 }
 
 // ListDeploymentTargets lists all deployment targets in a namespace
 func (c *Client) ListDeploymentTargets(namespace string) (*DeploymentTargetList, error) {
+	return c.ListDeploymentTargetsContext(context.Background(), namespace)
+}
+
+// ListDeploymentTargetsContext is like ListDeploymentTargets but carries ctx through the request
+func (c *Client) ListDeploymentTargetsContext(ctx context.Context, namespace string) (*DeploymentTargetList, error) {
 	var result DeploymentTargetList
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetResult(&result).
 		Get(fmt.Sprintf("/api/v1/namespaces/%s/deployment-targets", namespace))
 
@@ -59,8 +66,14 @@ func (c *Client) ListDeploymentTargets(namespace string) (*DeploymentTargetList,
 
 // GetDeploymentTarget gets a deployment target by name
 func (c *Client) GetDeploymentTarget(namespace, name string) (*DeploymentTargetResource, error) {
+	return c.GetDeploymentTargetContext(context.Background(), namespace, name)
+}
+
+// GetDeploymentTargetContext is like GetDeploymentTarget but carries ctx through the request
+func (c *Client) GetDeploymentTargetContext(ctx context.Context, namespace, name string) (*DeploymentTargetResource, error) {
 	var result DeploymentTargetResource
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetResult(&result).
 		Get(fmt.Sprintf("/api/v1/namespaces/%s/deployment-targets/%s", namespace, name))
 
@@ -73,8 +86,14 @@ func (c *Client) GetDeploymentTarget(namespace, name string) (*DeploymentTargetR
 
 // CreateDeploymentTarget creates a new deployment target
 func (c *Client) CreateDeploymentTarget(namespace string, target *DeploymentTargetResource) (*DeploymentTargetResource, error) {
+	return c.CreateDeploymentTargetContext(context.Background(), namespace, target)
+}
+
+// CreateDeploymentTargetContext is like CreateDeploymentTarget but carries ctx through the request
+func (c *Client) CreateDeploymentTargetContext(ctx context.Context, namespace string, target *DeploymentTargetResource) (*DeploymentTargetResource, error) {
 	var result DeploymentTargetResource
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetBody(target).
 		SetResult(&result).
 		Post(fmt.Sprintf("/api/v1/namespaces/%s/deployment-targets", namespace))
@@ -88,8 +107,14 @@ func (c *Client) CreateDeploymentTarget(namespace string, target *DeploymentTarg
 
 // UpdateDeploymentTarget updates an existing deployment target
 func (c *Client) UpdateDeploymentTarget(namespace, name string, target *DeploymentTargetResource) (*DeploymentTargetResource, error) {
+	return c.UpdateDeploymentTargetContext(context.Background(), namespace, name, target)
+}
+
+// UpdateDeploymentTargetContext is like UpdateDeploymentTarget but carries ctx through the request
+func (c *Client) UpdateDeploymentTargetContext(ctx context.Context, namespace, name string, target *DeploymentTargetResource) (*DeploymentTargetResource, error) {
 	var result DeploymentTargetResource
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetBody(target).
 		SetResult(&result).
 		Put(fmt.Sprintf("/api/v1/namespaces/%s/deployment-targets/%s", namespace, name))
@@ -103,7 +128,13 @@ func (c *Client) UpdateDeploymentTarget(namespace, name string, target *Deployme
 
 // DeleteDeploymentTarget deletes a deployment target
 func (c *Client) DeleteDeploymentTarget(namespace, name string) error {
+	return c.DeleteDeploymentTargetContext(context.Background(), namespace, name)
+}
+
+// DeleteDeploymentTargetContext is like DeleteDeploymentTarget but carries ctx through the request
+func (c *Client) DeleteDeploymentTargetContext(ctx context.Context, namespace, name string) error {
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		Delete(fmt.Sprintf("/api/v1/namespaces/%s/deployment-targets/%s", namespace, name))
 
 	return handleResponse(resp, err)
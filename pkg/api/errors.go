@@ -0,0 +1,136 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// requestIDHeaders are the response headers that may carry a request ID
+var requestIDHeaders = []string{"X-Request-Id", "X-Request-ID", "X-Correlation-Id"}
+
+// APIError represents an API error response. Reason, Message and Details are
+// parsed from the VVP error payload when the body is JSON; Body always holds
+// the raw response body.
+type APIError struct {
+	StatusCode int
+	Reason     string
+	Message    string
+	Details    string
+	RequestID  string
+	Body       string
+}
+
+// errorPayload is the JSON error body returned by VVP
+type errorPayload struct {
+	Reason    string          `json:"reason"`
+	Message   string          `json:"message"`
+	Details   json.RawMessage `json:"details"`
+	RequestID string          `json:"requestId"`
+}
+
+func (e *APIError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "API error (status %d", e.StatusCode)
+	if e.Reason != "" {
+		fmt.Fprintf(&sb, ", %s", e.Reason)
+	}
+	sb.WriteString("): ")
+
+	switch {
+	case e.Message != "":
+		sb.WriteString(e.Message)
+	case e.Body != "":
+		sb.WriteString(e.Body)
+	default:
+		sb.WriteString(http.StatusText(e.StatusCode))
+	}
+
+	if e.Details != "" {
+		fmt.Fprintf(&sb, " (%s)", e.Details)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&sb, " [request ID: %s]", e.RequestID)
+	}
+	return sb.String()
+}
+
+// newAPIError builds an APIError from an unsuccessful response
+func newAPIError(resp *resty.Response) *APIError {
+	body := bytes.TrimSpace(resp.Body())
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode(),
+		Body:       string(body),
+	}
+
+	var payload errorPayload
+	if json.Unmarshal(body, &payload) == nil {
+		apiErr.Reason = payload.Reason
+		apiErr.Message = payload.Message
+		apiErr.Details = detailsString(payload.Details)
+		apiErr.RequestID = payload.RequestID
+	}
+
+	if apiErr.RequestID == "" {
+		for _, header := range requestIDHeaders {
+			if id := resp.Header().Get(header); id != "" {
+				apiErr.RequestID = id
+				break
+			}
+		}
+	}
+	return apiErr
+}
+
+// detailsString renders the details field, which may be a string or any JSON value
+func detailsString(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var compact bytes.Buffer
+	if json.Compact(&compact, raw) != nil {
+		return string(raw)
+	}
+	return compact.String()
+}
+
+// StatusCode returns the HTTP status code of an API error, or 0 if err is not one
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is a 404 Not Found API error
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsConflict reports whether err is a 409 Conflict API error, e.g. when a
+// resource already exists or was modified concurrently
+func IsConflict(err error) bool {
+	return StatusCode(err) == http.StatusConflict
+}
+
+// IsUnauthorized reports whether err is a 401 Unauthorized or 403 Forbidden API error
+func IsUnauthorized(err error) bool {
+	code := StatusCode(err)
+	return code == http.StatusUnauthorized || code == http.StatusForbidden
+}
+
+// IsValidation reports whether err is an API error for an invalid request body
+func IsValidation(err error) bool {
+	code := StatusCode(err)
+	return code == http.StatusBadRequest || code == http.StatusUnprocessableEntity
+}
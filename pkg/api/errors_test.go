@@ -0,0 +1,106 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestAPIErrorParsesPayload(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"kind":"Error","reason":"NotFound","message":"Deployment 'job' not found","details":{"name":"job"}}`)
+	})
+
+	_, err := client.GetDeployment("default", "job")
+	if !IsNotFound(err) {
+		t.Fatalf("Expected not found error, got %v", err)
+	}
+
+	apiErr := err.(*APIError)
+	if apiErr.Reason != "NotFound" {
+		t.Errorf("Expected reason 'NotFound', got '%s'", apiErr.Reason)
+	}
+	if apiErr.Message != "Deployment 'job' not found" {
+		t.Errorf("Expected message to be parsed, got '%s'", apiErr.Message)
+	}
+	if apiErr.Details != `{"name":"job"}` {
+		t.Errorf("Expected details to be compact JSON, got '%s'", apiErr.Details)
+	}
+	if apiErr.RequestID != "req-123" {
+		t.Errorf("Expected request ID 'req-123', got '%s'", apiErr.RequestID)
+	}
+
+	expected := `API error (status 404, NotFound): Deployment 'job' not found ({"name":"job"}) [request ID: req-123]`
+	if err.Error() != expected {
+		t.Errorf("Expected error %q, got %q", expected, err.Error())
+	}
+}
+
+func TestAPIErrorPlainBody(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "upstream unavailable\n")
+	})
+
+	_, err := client.ListNamespaces()
+	expected := "API error (status 502): upstream unavailable"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected error %q, got %v", expected, err)
+	}
+}
+
+func TestAPIErrorEmptyBody(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	_, err := client.ListNamespaces()
+	if !IsUnauthorized(err) {
+		t.Errorf("Expected unauthorized error, got %v", err)
+	}
+	expected := "API error (status 401): Unauthorized"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected error %q, got %v", expected, err)
+	}
+}
+
+func TestErrorHelpers(t *testing.T) {
+	tests := []struct {
+		code       int
+		notFound   bool
+		conflict   bool
+		unauth     bool
+		validation bool
+	}{
+		{http.StatusNotFound, true, false, false, false},
+		{http.StatusConflict, false, true, false, false},
+		{http.StatusUnauthorized, false, false, true, false},
+		{http.StatusForbidden, false, false, true, false},
+		{http.StatusBadRequest, false, false, false, true},
+		{http.StatusUnprocessableEntity, false, false, false, true},
+		{http.StatusInternalServerError, false, false, false, false},
+	}
+
+	for _, tt := range tests {
+		err := fmt.Errorf("failed to get deployment: %w", &APIError{StatusCode: tt.code})
+		if IsNotFound(err) != tt.notFound {
+			t.Errorf("IsNotFound(%d): expected %v", tt.code, tt.notFound)
+		}
+		if IsConflict(err) != tt.conflict {
+			t.Errorf("IsConflict(%d): expected %v", tt.code, tt.conflict)
+		}
+		if IsUnauthorized(err) != tt.unauth {
+			t.Errorf("IsUnauthorized(%d): expected %v", tt.code, tt.unauth)
+		}
+		if IsValidation(err) != tt.validation {
+			t.Errorf("IsValidation(%d): expected %v", tt.code, tt.validation)
+		}
+	}
+
+	if IsNotFound(fmt.Errorf("request failed")) {
+		t.Error("Expected non-API error not to be reported as not found")
+	}
+}
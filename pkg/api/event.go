@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Event is a message VVP records about a deployment or job, such as a state
+// transition or the reason a job failed
+type Event struct {
+	APIVersion string        `json:"apiVersion,omitempty" yaml:"apiVersion,omitempty"`
+	Kind       string        `json:"kind,omitempty" yaml:"kind,omitempty"`
+	Metadata   EventMetadata `json:"metadata" yaml:"metadata"`
+	Spec       EventSpec     `json:"spec" yaml:"spec"`
+}
+
+// EventMetadata holds event metadata
+type EventMetadata struct {
+	ID           string            `json:"id,omitempty" yaml:"id,omitempty"`
+	Name         string            `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespace    string            `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	DeploymentID string            `json:"deploymentId,omitempty" yaml:"deploymentId,omitempty"`
+	JobID        string            `json:"jobId,omitempty" yaml:"jobId,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	CreatedAt    time.Time         `json:"createdAt,omitempty" yaml:"createdAt,omitempty"`
+}
+
+// EventSpec holds the event message
+type EventSpec struct {
+	Timestamp time.Time `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	Message   string    `json:"message,omitempty" yaml:"message,omitempty"`
+	Severity  string    `json:"severity,omitempty" yaml:"severity,omitempty"`
+}
+
+// Time returns when the event happened, falling back to its creation time
+func (e *Event) Time() time.Time {
+	if !e.Spec.Timestamp.IsZero() {
+		return e.Spec.Timestamp
+	}
+	return e.Metadata.CreatedAt
+}
+
+// EventList represents a list of events
+type EventList struct {
+	Items []Event `json:"items" yaml:"items"`
+}
+
+// EventListOptions filters the events returned by ListEvents
+type EventListOptions struct {
+	DeploymentID string
+	JobID        string
+}
+
+// ListEvents lists the events in a namespace, oldest first
+func (c *Client) ListEvents(namespace string, opts EventListOptions) ([]Event, error) {
+	return c.ListEventsContext(context.Background(), namespace, opts)
+}
+
+// ListEventsContext is like ListEvents but carries ctx through the request
+func (c *Client) ListEventsContext(ctx context.Context, namespace string, opts EventListOptions) ([]Event, error) {
+	req := c.httpClient.R().SetContext(ctx)
+	if opts.DeploymentID != "" {
+		req.SetQueryParam("deploymentId", opts.DeploymentID)
+	}
+	if opts.JobID != "" {
+		req.SetQueryParam("jobId", opts.JobID)
+	}
+
+	var result EventList
+	resp, err := req.
+		SetResult(&result).
+		Get(fmt.Sprintf("/api/v1/namespaces/%s/events", namespace))
+
+	if err := handleResponse(resp, err); err != nil {
+		return nil, err
+	}
+
+	// Filter client-side as well, in case the server ignores the parameters
+	events := make([]Event, 0, len(result.Items))
+	for _, event := range result.Items {
+		if opts.DeploymentID != "" && event.Metadata.DeploymentID != opts.DeploymentID {
+			continue
+		}
+		if opts.JobID != "" && event.Metadata.JobID != opts.JobID {
+			continue
+		}
+		events = append(events, event)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time().Before(events[j].Time())
+	})
+	return events, nil
+}
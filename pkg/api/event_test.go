@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestListEvents(t *testing.T) {
+	var query string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/default/events" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [
+			{"metadata": {"id": "e3", "deploymentId": "dep-1", "jobId": "job-2"},
+			 "spec": {"timestamp": "2024-05-01T12:02:00Z", "message": "Job failed", "severity": "ERROR"}},
+			{"metadata": {"id": "e1", "deploymentId": "dep-1", "jobId": "job-1", "createdAt": "2024-05-01T12:00:00Z"},
+			 "spec": {"message": "Job started"}},
+			{"metadata": {"id": "e2", "deploymentId": "dep-2"},
+			 "spec": {"timestamp": "2024-05-01T12:01:00Z", "message": "Other deployment"}}
+		]}`))
+	})
+
+	events, err := client.ListEvents("default", EventListOptions{DeploymentID: "dep-1"})
+	if err != nil {
+		t.Fatalf("Failed to list events: %v", err)
+	}
+	if query != "deploymentId=dep-1" {
+		t.Errorf("Expected deploymentId query parameter, got '%s'", query)
+	}
+
+	// Events of other deployments are dropped and the rest sorted oldest first
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[0].Metadata.ID != "e1" || events[1].Metadata.ID != "e3" {
+		t.Errorf("Expected events e1, e3, got %s, %s", events[0].Metadata.ID, events[1].Metadata.ID)
+	}
+	if events[1].Spec.Severity != "ERROR" {
+		t.Errorf("Expected severity 'ERROR', got '%s'", events[1].Spec.Severity)
+	}
+
+	events, err = client.ListEvents("default", EventListOptions{DeploymentID: "dep-1", JobID: "job-2"})
+	if err != nil {
+		t.Fatalf("Failed to list events: %v", err)
+	}
+	if len(events) != 1 || events[0].Metadata.ID != "e3" {
+		t.Errorf("Expected only event e3 for job-2, got %+v", events)
+	}
+}
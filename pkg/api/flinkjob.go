@@ -0,0 +1,254 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrJobNotRunning is returned when runtime details are requested for a job
+// that has no Flink job behind it
+var ErrJobNotRunning = errors.New("job is not running")
+
+// JobInspection summarizes the runtime state of a running job as reported by
+// the Flink REST API
+type JobInspection struct {
+	JobID         string           `json:"jobId" yaml:"jobId"`
+	FlinkJobID    string           `json:"flinkJobId" yaml:"flinkJobId"`
+	Name          string           `json:"name,omitempty" yaml:"name,omitempty"`
+	State         string           `json:"state" yaml:"state"`
+	StartTime     time.Time        `json:"startTime,omitempty" yaml:"startTime,omitempty"`
+	Checkpoints   *CheckpointStats `json:"checkpoints,omitempty" yaml:"checkpoints,omitempty"`
+	RootException *JobException    `json:"rootException,omitempty" yaml:"rootException,omitempty"`
+	Vertices      []VertexStats    `json:"vertices" yaml:"vertices"`
+}
+
+// CheckpointStats holds checkpoint counts and the latest completed and failed
+// checkpoints of a job
+type CheckpointStats struct {
+	Completed       int64                `json:"completed" yaml:"completed"`
+	Failed          int64                `json:"failed" yaml:"failed"`
+	InProgress      int64                `json:"inProgress" yaml:"inProgress"`
+	LatestCompleted *CompletedCheckpoint `json:"latestCompleted,omitempty" yaml:"latestCompleted,omitempty"`
+	LatestFailed    *FailedCheckpoint    `json:"latestFailed,omitempty" yaml:"latestFailed,omitempty"`
+}
+
+// CompletedCheckpoint describes a completed checkpoint. Size is the state
+// size in bytes.
+type CompletedCheckpoint struct {
+	ID         int64     `json:"id" yaml:"id"`
+	Time       time.Time `json:"time" yaml:"time"`
+	Size       int64     `json:"size" yaml:"size"`
+	DurationMs int64     `json:"durationMs" yaml:"durationMs"`
+	Savepoint  bool      `json:"savepoint,omitempty" yaml:"savepoint,omitempty"`
+}
+
+// FailedCheckpoint describes a failed checkpoint
+type FailedCheckpoint struct {
+	ID      int64     `json:"id" yaml:"id"`
+	Time    time.Time `json:"time" yaml:"time"`
+	Message string    `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// JobException is the most recent failure of a job
+type JobException struct {
+	Time       time.Time `json:"time,omitempty" yaml:"time,omitempty"`
+	TaskName   string    `json:"taskName,omitempty" yaml:"taskName,omitempty"`
+	StackTrace string    `json:"stackTrace" yaml:"stackTrace"`
+}
+
+// VertexStats holds the runtime figures of a job vertex (operator chain).
+// Backpressure is ok, low or high, or empty if Flink has no sample yet.
+type VertexStats struct {
+	ID           string `json:"id" yaml:"id"`
+	Name         string `json:"name" yaml:"name"`
+	Parallelism  int    `json:"parallelism" yaml:"parallelism"`
+	Status       string `json:"status" yaml:"status"`
+	Backpressure string `json:"backpressure,omitempty" yaml:"backpressure,omitempty"`
+	RecordsIn    int64  `json:"recordsIn" yaml:"recordsIn"`
+	RecordsOut   int64  `json:"recordsOut" yaml:"recordsOut"`
+}
+
+// flinkJobDetails is the Flink REST response for /jobs/:jobid
+type flinkJobDetails struct {
+	JID       string `json:"jid"`
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	StartTime int64  `json:"start-time"`
+	Vertices  []struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		Parallelism int    `json:"parallelism"`
+		Status      string `json:"status"`
+		Metrics     struct {
+			ReadRecords  int64 `json:"read-records"`
+			WriteRecords int64 `json:"write-records"`
+		} `json:"metrics"`
+	} `json:"vertices"`
+}
+
+// flinkCheckpoints is the Flink REST response for /jobs/:jobid/checkpoints
+type flinkCheckpoints struct {
+	Counts struct {
+		InProgress int64 `json:"in_progress"`
+		Completed  int64 `json:"completed"`
+		Failed     int64 `json:"failed"`
+	} `json:"counts"`
+	Latest struct {
+		Completed *struct {
+			ID               int64 `json:"id"`
+			IsSavepoint      bool  `json:"is_savepoint"`
+			TriggerTimestamp int64 `json:"trigger_timestamp"`
+			StateSize        int64 `json:"state_size"`
+			EndToEndDuration int64 `json:"end_to_end_duration"`
+		} `json:"completed"`
+		Failed *struct {
+			ID               int64  `json:"id"`
+			FailureTimestamp int64  `json:"failure_timestamp"`
+			FailureMessage   string `json:"failure_message"`
+		} `json:"failed"`
+	} `json:"latest"`
+}
+
+// flinkExceptions is the Flink REST response for /jobs/:jobid/exceptions.
+// Newer Flink versions only fill exceptionHistory, newest entry first.
+type flinkExceptions struct {
+	RootException    string `json:"root-exception"`
+	Timestamp        int64  `json:"timestamp"`
+	ExceptionHistory struct {
+		Entries []struct {
+			Stacktrace string `json:"stacktrace"`
+			Timestamp  int64  `json:"timestamp"`
+			TaskName   string `json:"taskName"`
+		} `json:"entries"`
+	} `json:"exceptionHistory"`
+}
+
+// flinkBackPressure is the Flink REST response for
+// /jobs/:jobid/vertices/:vertexid/backpressure
+type flinkBackPressure struct {
+	Status string `json:"status"`
+	Level  string `json:"backpressure-level"`
+}
+
+// InspectJob collects checkpoint, exception and vertex details of a running
+// job through the Flink UI proxy
+func (c *Client) InspectJob(namespace, jobID string) (*JobInspection, error) {
+	return c.InspectJobContext(context.Background(), namespace, jobID)
+}
+
+// InspectJobContext is like InspectJob but carries ctx through the request
+func (c *Client) InspectJobContext(ctx context.Context, namespace, jobID string) (*JobInspection, error) {
+	job, err := c.GetJobContext(ctx, namespace, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status.Running == nil || job.Status.Running.JobID == "" {
+		return nil, fmt.Errorf("%w (state %s)", ErrJobNotRunning, job.Status.State)
+	}
+	flinkJobID := job.Status.Running.JobID
+	jobPath := func(path string) string {
+		return flinkUIPath(namespace, jobID, "jobs/"+flinkJobID+path)
+	}
+
+	var details flinkJobDetails
+	if err := c.getFlinkUI(ctx, jobPath(""), &details); err != nil {
+		return nil, fmt.Errorf("failed to get Flink job details: %w", err)
+	}
+	inspection := &JobInspection{
+		JobID:      jobID,
+		FlinkJobID: flinkJobID,
+		Name:       details.Name,
+		State:      details.State,
+		StartTime:  millisToTime(details.StartTime),
+		Vertices:   make([]VertexStats, 0, len(details.Vertices)),
+	}
+
+	// Flink answers 404 if checkpointing is not enabled for the job
+	var checkpoints flinkCheckpoints
+	if err := c.getFlinkUI(ctx, jobPath("/checkpoints"), &checkpoints); err == nil {
+		inspection.Checkpoints = newCheckpointStats(&checkpoints)
+	} else if !IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get checkpoint statistics: %w", err)
+	}
+
+	var exceptions flinkExceptions
+	if err := c.getFlinkUI(ctx, jobPath("/exceptions"), &exceptions); err != nil {
+		return nil, fmt.Errorf("failed to get job exceptions: %w", err)
+	}
+	inspection.RootException = newJobException(&exceptions)
+
+	for _, v := range details.Vertices {
+		vertex := VertexStats{
+			ID:          v.ID,
+			Name:        v.Name,
+			Parallelism: v.Parallelism,
+			Status:      v.Status,
+			RecordsIn:   v.Metrics.ReadRecords,
+			RecordsOut:  v.Metrics.WriteRecords,
+		}
+		// Backpressure is best effort: a vertex that is not sampled yet, or a
+		// failed sample, should not hide the rest of the picture
+		var bp flinkBackPressure
+		if err := c.getFlinkUI(ctx, jobPath("/vertices/"+v.ID+"/backpressure"), &bp); err == nil && bp.Status == "ok" {
+			vertex.Backpressure = bp.Level
+		} else if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		inspection.Vertices = append(inspection.Vertices, vertex)
+	}
+
+	return inspection, nil
+}
+
+func newCheckpointStats(cp *flinkCheckpoints) *CheckpointStats {
+	stats := &CheckpointStats{
+		Completed:  cp.Counts.Completed,
+		Failed:     cp.Counts.Failed,
+		InProgress: cp.Counts.InProgress,
+	}
+	if completed := cp.Latest.Completed; completed != nil {
+		stats.LatestCompleted = &CompletedCheckpoint{
+			ID:         completed.ID,
+			Time:       millisToTime(completed.TriggerTimestamp),
+			Size:       completed.StateSize,
+			DurationMs: completed.EndToEndDuration,
+			Savepoint:  completed.IsSavepoint,
+		}
+	}
+	if failed := cp.Latest.Failed; failed != nil {
+		stats.LatestFailed = &FailedCheckpoint{
+			ID:      failed.ID,
+			Time:    millisToTime(failed.FailureTimestamp),
+			Message: failed.FailureMessage,
+		}
+	}
+	return stats
+}
+
+func newJobException(ex *flinkExceptions) *JobException {
+	if ex.RootException != "" {
+		return &JobException{
+			Time:       millisToTime(ex.Timestamp),
+			StackTrace: ex.RootException,
+		}
+	}
+	if entries := ex.ExceptionHistory.Entries; len(entries) > 0 {
+		return &JobException{
+			Time:       millisToTime(entries[0].Timestamp),
+			TaskName:   entries[0].TaskName,
+			StackTrace: entries[0].Stacktrace,
+		}
+	}
+	return nil
+}
+
+// millisToTime converts a Flink epoch milliseconds timestamp. Flink reports
+// 0 or -1 for unset timestamps.
+func millisToTime(ms int64) time.Time {
+	if ms <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms).UTC()
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestInspectJob(t *testing.T) {
+	const prefix = "/flink-ui/v1/namespaces/default/jobs/job-1/jobs/f-1"
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/namespaces/default/jobs/job-1":
+			w.Write([]byte(`{"metadata": {"id": "job-1"}, "status": {"state": "STARTED", "running": {"jobId": "f-1"}}}`))
+		case "/api/v1/namespaces/default/jobs/job-2":
+			w.Write([]byte(`{"metadata": {"id": "job-2"}, "status": {"state": "FAILED"}}`))
+		case prefix:
+			w.Write([]byte(`{"jid": "f-1", "name": "orders", "state": "RUNNING", "start-time": 1714564800000,
+				"vertices": [
+					{"id": "v1", "name": "Source: Kafka", "parallelism": 2, "status": "RUNNING",
+					 "metrics": {"read-records": 0, "write-records": 1500}},
+					{"id": "v2", "name": "Sink: Print", "parallelism": 4, "status": "RUNNING",
+					 "metrics": {"read-records": 1500, "write-records": 0}}
+				]}`))
+		case prefix + "/checkpoints":
+			w.Write([]byte(`{"counts": {"in_progress": 1, "completed": 10, "failed": 2},
+				"latest": {
+					"completed": {"id": 12, "trigger_timestamp": 1714565000000, "state_size": 2048, "end_to_end_duration": 350},
+					"failed": {"id": 11, "failure_timestamp": 1714564900000, "failure_message": "Checkpoint expired"}
+				}}`))
+		case prefix + "/exceptions":
+			w.Write([]byte(`{"exceptionHistory": {"entries": [
+				{"stacktrace": "java.lang.RuntimeException: boom\n\tat Foo", "timestamp": 1714564950000, "taskName": "Sink: Print (1/4)"},
+				{"stacktrace": "older", "timestamp": 1714564000000}
+			]}}`))
+		case prefix + "/vertices/v1/backpressure":
+			w.Write([]byte(`{"status": "ok", "backpressure-level": "high"}`))
+		case prefix + "/vertices/v2/backpressure":
+			w.Write([]byte(`{"status": "deprecated"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	inspection, err := client.InspectJob("default", "job-1")
+	if err != nil {
+		t.Fatalf("Failed to inspect job: %v", err)
+	}
+	if inspection.FlinkJobID != "f-1" || inspection.State != "RUNNING" || inspection.StartTime.IsZero() {
+		t.Errorf("Unexpected job summary %+v", inspection)
+	}
+
+	cp := inspection.Checkpoints
+	if cp == nil || cp.Completed != 10 || cp.Failed != 2 || cp.InProgress != 1 {
+		t.Fatalf("Unexpected checkpoint counts %+v", cp)
+	}
+	if cp.LatestCompleted == nil || cp.LatestCompleted.Size != 2048 || cp.LatestCompleted.DurationMs != 350 {
+		t.Errorf("Unexpected latest completed checkpoint %+v", cp.LatestCompleted)
+	}
+	if cp.LatestFailed == nil || cp.LatestFailed.Message != "Checkpoint expired" {
+		t.Errorf("Unexpected latest failed checkpoint %+v", cp.LatestFailed)
+	}
+
+	// The newest exception history entry stands in for the root exception
+	ex := inspection.RootException
+	if ex == nil || !strings.HasPrefix(ex.StackTrace, "java.lang.RuntimeException: boom") || ex.TaskName != "Sink: Print (1/4)" {
+		t.Errorf("Unexpected root exception %+v", ex)
+	}
+
+	if len(inspection.Vertices) != 2 {
+		t.Fatalf("Expected 2 vertices, got %d", len(inspection.Vertices))
+	}
+	if v := inspection.Vertices[0]; v.Backpressure != "high" || v.RecordsOut != 1500 || v.Parallelism != 2 {
+		t.Errorf("Unexpected vertex %+v", v)
+	}
+	// A vertex without a backpressure sample is reported without a level
+	if v := inspection.Vertices[1]; v.Backpressure != "" || v.RecordsIn != 1500 {
+		t.Errorf("Unexpected vertex %+v", v)
+	}
+
+	if _, err := client.InspectJob("default", "job-2"); !errors.Is(err, ErrJobNotRunning) {
+		t.Errorf("Expected ErrJobNotRunning, got %v", err)
+	}
+}
+
+func TestInspectJobWithoutCheckpointing(t *testing.T) {
+	const prefix = "/flink-ui/v1/namespaces/default/jobs/job-1/jobs/f-1"
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/namespaces/default/jobs/job-1":
+			w.Write([]byte(`{"metadata": {"id": "job-1"}, "status": {"state": "STARTED", "running": {"jobId": "f-1"}}}`))
+		case prefix:
+			w.Write([]byte(`{"jid": "f-1", "state": "RUNNING", "vertices": []}`))
+		case prefix + "/exceptions":
+			w.Write([]byte(`{"root-exception": "java.io.IOException: disk full", "timestamp": 1714564950000}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	inspection, err := client.InspectJob("default", "job-1")
+	if err != nil {
+		t.Fatalf("Failed to inspect job: %v", err)
+	}
+	if inspection.Checkpoints != nil {
+		t.Errorf("Expected no checkpoint statistics, got %+v", inspection.Checkpoints)
+	}
+	if inspection.RootException == nil || inspection.RootException.StackTrace != "java.io.IOException: disk full" {
+		t.Errorf("Unexpected root exception %+v", inspection.RootException)
+	}
+}
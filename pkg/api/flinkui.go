@@ -0,0 +1,208 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrNoRunningJob is returned when a deployment has no running job, so there
+// is no Flink cluster to proxy to
+var ErrNoRunningJob = errors.New("deployment has no running job")
+
+// TaskManager is a Flink TaskManager as reported by the Flink REST API
+type TaskManager struct {
+	ID                     string `json:"id" yaml:"id"`
+	Path                   string `json:"path,omitempty" yaml:"path,omitempty"`
+	DataPort               int    `json:"dataPort,omitempty" yaml:"dataPort,omitempty"`
+	SlotsNumber            int    `json:"slotsNumber,omitempty" yaml:"slotsNumber,omitempty"`
+	FreeSlots              int    `json:"freeSlots,omitempty" yaml:"freeSlots,omitempty"`
+	TimeSinceLastHeartbeat int64  `json:"timeSinceLastHeartbeat,omitempty" yaml:"timeSinceLastHeartbeat,omitempty"`
+}
+
+// taskManagerList is the Flink REST response for /taskmanagers
+type taskManagerList struct {
+	TaskManagers []TaskManager `json:"taskmanagers"`
+}
+
+// flinkUIPath returns the path of a Flink REST resource behind the VVP Flink UI
+// proxy of a job. jobID is the VVP job ID (metadata.id).
+func flinkUIPath(namespace, jobID, path string) string {
+	return fmt.Sprintf("/flink-ui/v1/namespaces/%s/jobs/%s/%s", namespace, jobID, strings.TrimPrefix(path, "/"))
+}
+
+// CurrentDeploymentJob returns the running job of a deployment, i.e. the job
+// in state STARTED whose status carries a Flink job ID. It returns
+// ErrNoRunningJob if there is none, before any request to the Flink UI proxy
+// could fail on a stopped job.
+func (c *Client) CurrentDeploymentJob(namespace, deploymentID string) (*Job, error) {
+	return c.CurrentDeploymentJobContext(context.Background(), namespace, deploymentID)
+}
+
+// CurrentDeploymentJobContext is like CurrentDeploymentJob but carries ctx through the request
+func (c *Client) CurrentDeploymentJobContext(ctx context.Context, namespace, deploymentID string) (*Job, error) {
+	jobs, err := c.ListJobsContext(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var current *Job
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if job.Spec.DeploymentID != deploymentID || !jobRunning(job) {
+			continue
+		}
+		// Prefer the most recently started job if several report running
+		if current == nil || job.Status.Running.StartTime.After(current.Status.Running.StartTime) {
+			current = job
+		}
+	}
+	if current == nil {
+		return nil, ErrNoRunningJob
+	}
+	return current, nil
+}
+
+// jobRunning reports whether a job has a running Flink job behind it
+func jobRunning(job *Job) bool {
+	return job.Status.State == "STARTED" && job.Status.Running != nil && job.Status.Running.JobID != ""
+}
+
+// LogChunk is the part of a log file from Offset on
+type LogChunk struct {
+	Content string
+	// Offset is where Content starts in the log file. It is 0 when the whole
+	// log was returned, either because the server ignores ranges or because
+	// the log was rotated and is shorter than the requested offset.
+	Offset int64
+}
+
+// GetJobManagerLog returns the JobManager log of a running job
+func (c *Client) GetJobManagerLog(namespace, jobID string) (string, error) {
+	return c.GetJobManagerLogContext(context.Background(), namespace, jobID)
+}
+
+// GetJobManagerLogContext is like GetJobManagerLog but carries ctx through the request
+func (c *Client) GetJobManagerLogContext(ctx context.Context, namespace, jobID string) (string, error) {
+	return c.getFlinkUIText(ctx, flinkUIPath(namespace, jobID, "jobmanager/log"))
+}
+
+// GetJobManagerLogFrom returns the JobManager log of a running job from
+// offset on, so that following a log only transfers what was appended
+func (c *Client) GetJobManagerLogFrom(namespace, jobID string, offset int64) (*LogChunk, error) {
+	return c.GetJobManagerLogFromContext(context.Background(), namespace, jobID, offset)
+}
+
+// GetJobManagerLogFromContext is like GetJobManagerLogFrom but carries ctx through the request
+func (c *Client) GetJobManagerLogFromContext(ctx context.Context, namespace, jobID string, offset int64) (*LogChunk, error) {
+	return c.getFlinkUITextFrom(ctx, flinkUIPath(namespace, jobID, "jobmanager/log"), offset)
+}
+
+// ListTaskManagers lists the TaskManagers of a running job
+func (c *Client) ListTaskManagers(namespace, jobID string) ([]TaskManager, error) {
+	return c.ListTaskManagersContext(context.Background(), namespace, jobID)
+}
+
+// ListTaskManagersContext is like ListTaskManagers but carries ctx through the request
+func (c *Client) ListTaskManagersContext(ctx context.Context, namespace, jobID string) ([]TaskManager, error) {
+	var result taskManagerList
+	if err := c.getFlinkUI(ctx, flinkUIPath(namespace, jobID, "taskmanagers"), &result); err != nil {
+		return nil, err
+	}
+	return result.TaskManagers, nil
+}
+
+// GetTaskManagerLog returns the log of a TaskManager of a running job
+func (c *Client) GetTaskManagerLog(namespace, jobID, taskManagerID string) (string, error) {
+	return c.GetTaskManagerLogContext(context.Background(), namespace, jobID, taskManagerID)
+}
+
+// GetTaskManagerLogContext is like GetTaskManagerLog but carries ctx through the request
+func (c *Client) GetTaskManagerLogContext(ctx context.Context, namespace, jobID, taskManagerID string) (string, error) {
+	path := flinkUIPath(namespace, jobID, "taskmanagers/"+url.PathEscape(taskManagerID)+"/log")
+	return c.getFlinkUIText(ctx, path)
+}
+
+// GetTaskManagerLogFrom returns the log of a TaskManager of a running job
+// from offset on
+func (c *Client) GetTaskManagerLogFrom(namespace, jobID, taskManagerID string, offset int64) (*LogChunk, error) {
+	return c.GetTaskManagerLogFromContext(context.Background(), namespace, jobID, taskManagerID, offset)
+}
+
+// GetTaskManagerLogFromContext is like GetTaskManagerLogFrom but carries ctx through the request
+func (c *Client) GetTaskManagerLogFromContext(ctx context.Context, namespace, jobID, taskManagerID string, offset int64) (*LogChunk, error) {
+	path := flinkUIPath(namespace, jobID, "taskmanagers/"+url.PathEscape(taskManagerID)+"/log")
+	return c.getFlinkUITextFrom(ctx, path, offset)
+}
+
+// getFlinkUI decodes a JSON resource from the Flink UI proxy into result
+func (c *Client) getFlinkUI(ctx context.Context, path string, result interface{}) error {
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetResult(result).
+		Get(path)
+
+	return handleResponse(resp, err)
+}
+
+// getFlinkUIText returns a plain text resource, such as a log file, from the
+// Flink UI proxy. Logs can be large, so the request is bounded by ctx only.
+func (c *Client) getFlinkUIText(ctx context.Context, path string) (string, error) {
+	resp, err := c.transferClient.R().
+		SetContext(ctx).
+		Get(path)
+
+	if err := handleResponse(resp, err); err != nil {
+		return "", err
+	}
+	return string(resp.Body()), nil
+}
+
+// getFlinkUITextFrom requests a plain text resource from offset on with an
+// HTTP range request. Servers that ignore the range answer with the whole
+// resource, which is returned with Offset 0.
+func (c *Client) getFlinkUITextFrom(ctx context.Context, path string, offset int64) (*LogChunk, error) {
+	if offset <= 0 {
+		text, err := c.getFlinkUIText(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		return &LogChunk{Content: text}, nil
+	}
+
+	resp, err := c.transferClient.R().
+		SetContext(ctx).
+		SetHeader("Range", fmt.Sprintf("bytes=%d-", offset)).
+		Get(path)
+	if err == nil {
+		switch resp.StatusCode() {
+		case http.StatusPartialContent:
+			return &LogChunk{Content: string(resp.Body()), Offset: offset}, nil
+		case http.StatusRequestedRangeNotSatisfiable:
+			// Nothing was appended, unless the log is now shorter than
+			// offset, i.e. it was rotated
+			if size, ok := rangeSize(resp.Header().Get("Content-Range")); !ok || size >= offset {
+				return &LogChunk{Offset: offset}, nil
+			}
+			return c.getFlinkUITextFrom(ctx, path, 0)
+		}
+	}
+	if err := handleResponse(resp, err); err != nil {
+		return nil, err
+	}
+	return &LogChunk{Content: string(resp.Body())}, nil
+}
+
+// rangeSize returns the complete length of a "bytes */length" Content-Range
+func rangeSize(contentRange string) (int64, bool) {
+	_, size, found := strings.Cut(contentRange, "/")
+	if !found {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(size), 10, 64)
+	return n, err == nil
+}
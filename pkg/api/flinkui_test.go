@@ -0,0 +1,117 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCurrentDeploymentJob(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [
+			{"metadata": {"id": "old"}, "spec": {"deploymentId": "dep-1"},
+			 "status": {"state": "FAILED"}},
+			{"metadata": {"id": "cancelled"}, "spec": {"deploymentId": "dep-1"},
+			 "status": {"state": "CANCELLED", "running": {"jobId": "f-cancelled", "startTime": "2024-05-01T14:00:00Z"}}},
+			{"metadata": {"id": "stopped"}, "spec": {"deploymentId": "dep-3"},
+			 "status": {"state": "FINISHED", "running": {"jobId": "f-stopped", "startTime": "2024-05-01T14:00:00Z"}}},
+			{"metadata": {"id": "other"}, "spec": {"deploymentId": "dep-2"},
+			 "status": {"state": "STARTED", "running": {"jobId": "f-other", "startTime": "2024-05-01T13:00:00Z"}}},
+			{"metadata": {"id": "current"}, "spec": {"deploymentId": "dep-1"},
+			 "status": {"state": "STARTED", "running": {"jobId": "f-current", "startTime": "2024-05-01T12:00:00Z"}}}
+		]}`))
+	})
+
+	job, err := client.CurrentDeploymentJob("default", "dep-1")
+	if err != nil {
+		t.Fatalf("Failed to find current job: %v", err)
+	}
+	if job.Metadata.ID != "current" || job.Status.Running.JobID != "f-current" {
+		t.Errorf("Expected job 'current', got %+v", job.Metadata)
+	}
+
+	if _, err := client.CurrentDeploymentJob("default", "dep-3"); !errors.Is(err, ErrNoRunningJob) {
+		t.Errorf("Expected ErrNoRunningJob, got %v", err)
+	}
+}
+
+func TestFlinkUILogs(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/flink-ui/v1/namespaces/default/jobs/job-1/jobmanager/log":
+			w.Write([]byte("jobmanager line 1\njobmanager line 2\n"))
+		case "/flink-ui/v1/namespaces/default/jobs/job-1/taskmanagers":
+			writeJSON(w, taskManagerList{TaskManagers: []TaskManager{{ID: "tm-1", SlotsNumber: 2}}})
+		case "/flink-ui/v1/namespaces/default/jobs/job-1/taskmanagers/tm-1/log":
+			w.Write([]byte("taskmanager line\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	log, err := client.GetJobManagerLog("default", "job-1")
+	if err != nil || log != "jobmanager line 1\njobmanager line 2\n" {
+		t.Errorf("Unexpected JobManager log %q (%v)", log, err)
+	}
+
+	taskManagers, err := client.ListTaskManagers("default", "job-1")
+	if err != nil || len(taskManagers) != 1 || taskManagers[0].ID != "tm-1" {
+		t.Fatalf("Unexpected TaskManagers %+v (%v)", taskManagers, err)
+	}
+
+	log, err = client.GetTaskManagerLog("default", "job-1", "tm-1")
+	if err != nil || log != "taskmanager line\n" {
+		t.Errorf("Unexpected TaskManager log %q (%v)", log, err)
+	}
+
+	if _, err := client.GetTaskManagerLog("default", "job-1", "tm-2"); !IsNotFound(err) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestFlinkUILogFrom(t *testing.T) {
+	const log = "line 1\nline 2\nline 3\n"
+	ignoreRange := false
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		rng := r.Header.Get("Range")
+		if ignoreRange || rng == "" {
+			w.Write([]byte(log))
+			return
+		}
+		var offset int
+		fmt.Sscanf(rng, "bytes=%d-", &offset)
+		if offset >= len(log) {
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", len(log)))
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, len(log)-1, len(log)))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte(log[offset:]))
+	})
+
+	tests := []struct {
+		name        string
+		offset      int64
+		ignoreRange bool
+		want        LogChunk
+	}{
+		{"whole log", 0, false, LogChunk{Content: log}},
+		{"appended lines", 7, false, LogChunk{Content: "line 2\nline 3\n", Offset: 7}},
+		{"nothing appended", int64(len(log)), false, LogChunk{Offset: int64(len(log))}},
+		{"rotated log", 100, false, LogChunk{Content: log}},
+		{"range ignored", 7, true, LogChunk{Content: log}},
+	}
+	for _, tt := range tests {
+		ignoreRange = tt.ignoreRange
+		chunk, err := client.GetJobManagerLogFrom("default", "job-1", tt.offset)
+		if err != nil {
+			t.Fatalf("%s: failed to fetch log: %v", tt.name, err)
+		}
+		if *chunk != tt.want {
+			t.Errorf("%s: expected %+v, got %+v", tt.name, tt.want, *chunk)
+		}
+	}
+}
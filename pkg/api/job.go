@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -86,8 +87,14 @@ type JobList struct {
 
 // ListJobs lists all jobs in a namespace
 func (c *Client) ListJobs(namespace string) (*JobList, error) {
+	return c.ListJobsContext(context.Background(), namespace)
+}
+
+// ListJobsContext is like ListJobs but carries ctx through the request
+func (c *Client) ListJobsContext(ctx context.Context, namespace string) (*JobList, error) {
 	var result JobList
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetResult(&result).
 		Get(fmt.Sprintf("/api/v1/namespaces/%s/jobs", namespace))
 
@@ -100,8 +107,14 @@ func (c *Client) ListJobs(namespace string) (*JobList, error) {
 
 // GetJob gets a job by ID
 func (c *Client) GetJob(namespace, jobID string) (*Job, error) {
+	return c.GetJobContext(context.Background(), namespace, jobID)
+}
+
+// GetJobContext is like GetJob but carries ctx through the request
+func (c *Client) GetJobContext(ctx context.Context, namespace, jobID string) (*Job, error) {
 	var result Job
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetResult(&result).
 		Get(fmt.Sprintf("/api/v1/namespaces/%s/jobs/%s", namespace, jobID))
 
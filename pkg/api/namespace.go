@@ -1,52 +1,59 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
 
 // Namespace represents a VVP namespace
 type Namespace struct {
-	Metadata NamespaceMetadata `json:"metadata"`
-	Spec     NamespaceSpec     `json:"spec,omitempty"`
-	Status   NamespaceStatus   `json:"status,omitempty"`
+	Metadata NamespaceMetadata `json:"metadata" yaml:"metadata"`
+	Spec     NamespaceSpec     `json:"spec,omitempty" yaml:"spec,omitempty"`
+	Status   NamespaceStatus   `json:"status,omitempty" yaml:"status,omitempty"`
 }
 
 // NamespaceMetadata holds namespace metadata
 type NamespaceMetadata struct {
-	ID          string            `json:"id,omitempty"`
-	Name        string            `json:"name"`
-	Labels      map[string]string `json:"labels,omitempty"`
-	Annotations map[string]string `json:"annotations,omitempty"`
-	CreatedAt   time.Time         `json:"createdAt,omitempty"`
-	ModifiedAt  time.Time         `json:"modifiedAt,omitempty"`
+	ID          string            `json:"id,omitempty" yaml:"id,omitempty"`
+	Name        string            `json:"name" yaml:"name"`
+	Labels      map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	CreatedAt   time.Time         `json:"createdAt,omitempty" yaml:"createdAt,omitempty"`
+	ModifiedAt  time.Time         `json:"modifiedAt,omitempty" yaml:"modifiedAt,omitempty"`
 }
 
 // NamespaceSpec holds namespace specification
 type NamespaceSpec struct {
-	RoleBindings []RoleBinding `json:"roleBindings,omitempty"`
+	RoleBindings []RoleBinding `json:"roleBindings,omitempty" yaml:"roleBindings,omitempty"`
 }
 
 // NamespaceStatus holds namespace status
 type NamespaceStatus struct {
-	State string `json:"state,omitempty"`
+	State string `json:"state,omitempty" yaml:"state,omitempty"`
 }
 
 // RoleBinding represents a role binding
 type RoleBinding struct {
-	Role    string   `json:"role"`
-	Members []string `json:"members"`
+	Role    string   `json:"role" yaml:"role"`
+	Members []string `json:"members" yaml:"members"`
 }
 
 // NamespaceList represents a list of namespaces
 type NamespaceList struct {
-	Items []Namespace `json:"items"`
+	Items []Namespace `json:"items" yaml:"items"`
 }
 
 // ListNamespaces lists all namespaces
 func (c *Client) ListNamespaces() (*NamespaceList, error) {
+	return c.ListNamespacesContext(context.Background())
+}
+
+// ListNamespacesContext is like ListNamespaces but carries ctx through the request
+func (c *Client) ListNamespacesContext(ctx context.Context) (*NamespaceList, error) {
 	var result NamespaceList
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetResult(&result).
 		Get("/namespaces/v1/namespaces")
 
@@ -59,8 +66,14 @@ func (c *Client) ListNamespaces() (*NamespaceList, error) {
 
 // GetNamespace gets a namespace by name
 func (c *Client) GetNamespace(name string) (*Namespace, error) {
+	return c.GetNamespaceContext(context.Background(), name)
+}
+
+// GetNamespaceContext is like GetNamespace but carries ctx through the request
+func (c *Client) GetNamespaceContext(ctx context.Context, name string) (*Namespace, error) {
 	var result Namespace
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetResult(&result).
 		Get(fmt.Sprintf("/namespaces/v1/namespaces/%s", name))
 
@@ -73,8 +86,14 @@ func (c *Client) GetNamespace(name string) (*Namespace, error) {
 
 // CreateNamespace creates a new namespace
 func (c *Client) CreateNamespace(namespace *Namespace) (*Namespace, error) {
+	return c.CreateNamespaceContext(context.Background(), namespace)
+}
+
+// CreateNamespaceContext is like CreateNamespace but carries ctx through the request
+func (c *Client) CreateNamespaceContext(ctx context.Context, namespace *Namespace) (*Namespace, error) {
 	var result Namespace
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetBody(namespace).
 		SetResult(&result).
 		Post("/namespaces/v1/namespaces")
@@ -88,8 +107,14 @@ func (c *Client) CreateNamespace(namespace *Namespace) (*Namespace, error) {
 
 // UpdateNamespace updates an existing namespace
 func (c *Client) UpdateNamespace(name string, namespace *Namespace) (*Namespace, error) {
+	return c.UpdateNamespaceContext(context.Background(), name, namespace)
+}
+
+// UpdateNamespaceContext is like UpdateNamespace but carries ctx through the request
+func (c *Client) UpdateNamespaceContext(ctx context.Context, name string, namespace *Namespace) (*Namespace, error) {
 	var result Namespace
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetBody(namespace).
 		SetResult(&result).
 		Put(fmt.Sprintf("/namespaces/v1/namespaces/%s", name))
@@ -103,7 +128,13 @@ func (c *Client) UpdateNamespace(name string, namespace *Namespace) (*Namespace,
 
 // DeleteNamespace deletes a namespace
 func (c *Client) DeleteNamespace(name string) error {
+	return c.DeleteNamespaceContext(context.Background(), name)
+}
+
+// DeleteNamespaceContext is like DeleteNamespace but carries ctx through the request
+func (c *Client) DeleteNamespaceContext(ctx context.Context, name string) error {
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		Delete(fmt.Sprintf("/namespaces/v1/namespaces/%s", name))
 
 	return handleResponse(resp, err)
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"mcolomerc/vvp2cli/pkg/config"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// idempotentMethods can be repeated safely, so they are retried by default
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// retryableStatusCodes are transient gateway and throttling responses
+var retryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// configureRetries enables retries with exponential backoff on the HTTP client
+func configureRetries(httpClient *resty.Client, cfg *config.Config) {
+	if cfg.API.Retries <= 0 {
+		return
+	}
+
+	httpClient.
+		SetRetryCount(cfg.API.Retries).
+		SetRetryWaitTime(cfg.GetRetryWaitTime()).
+		SetRetryMaxWaitTime(cfg.GetRetryMaxWaitTime()).
+		SetRetryAfter(retryAfter).
+		AddRetryCondition(retryCondition(cfg.API.RetryAllMethods))
+}
+
+// noRetryKey marks a request context whose requests must not be retried
+type noRetryKey struct{}
+
+// withoutRetries returns a context whose requests are never retried, e.g.
+// uploads whose streamed body cannot be sent a second time
+func withoutRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
+// retryCondition reports whether a request should be retried: connection
+// errors and retryable status codes, for idempotent methods unless allMethods
+// is set. Cancelled requests and those made withoutRetries are never retried.
+func retryCondition(allMethods bool) resty.RetryConditionFunc {
+	return func(resp *resty.Response, err error) bool {
+		if resp == nil || resp.Request == nil {
+			return false
+		}
+		if resp.Request.Context().Value(noRetryKey{}) != nil {
+			return false
+		}
+		if !allMethods && !idempotentMethods[resp.Request.Method] {
+			return false
+		}
+		if err != nil {
+			return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+		}
+		return retryableStatusCodes[resp.StatusCode()]
+	}
+}
+
+// retryAfter honors the Retry-After header (seconds or HTTP date). Returning
+// zero falls back to exponential backoff.
+func retryAfter(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+	if resp == nil {
+		return 0, nil
+	}
+	value := strings.TrimSpace(resp.Header().Get("Retry-After"))
+	if value == "" {
+		return 0, nil
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(when); wait > 0 {
+			return wait, nil
+		}
+	}
+	return 0, nil
+}
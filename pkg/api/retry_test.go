@@ -0,0 +1,147 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"mcolomerc/vvp2cli/pkg/config"
+)
+
+// newRetryTestClient returns a client with fast retries pointed at a test server
+func newRetryTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	return newRetryAllMethodsTestClient(t, handler, false)
+}
+
+// newRetryAllMethodsTestClient is like newRetryTestClient, optionally with
+// retryAllMethods set
+func newRetryAllMethodsTestClient(t *testing.T, handler http.HandlerFunc, allMethods bool) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&config.Config{API: config.APIConfig{
+		URL:              server.URL,
+		Retries:          3,
+		RetryWaitTime:    time.Millisecond,
+		RetryMaxWaitTime: 5 * time.Millisecond,
+		RetryAllMethods:  allMethods,
+	}})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestRetryOnServiceUnavailable(t *testing.T) {
+	calls := 0
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writeJSON(w, Namespace{Metadata: NamespaceMetadata{Name: "default"}})
+	})
+
+	if _, err := client.GetNamespace("default"); err != nil {
+		t.Fatalf("Expected request to succeed after retries, got error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	calls := 0
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	if _, err := client.ListNamespaces(); err == nil {
+		t.Error("Expected error after exhausting retries")
+	}
+	if calls != 4 {
+		t.Errorf("Expected 4 attempts (1 + 3 retries), got %d", calls)
+	}
+}
+
+func TestNoRetryForPost(t *testing.T) {
+	calls := 0
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	if _, err := client.CreateNamespace(&Namespace{Metadata: NamespaceMetadata{Name: "test"}}); err == nil {
+		t.Error("Expected error for failed POST")
+	}
+	if calls != 1 {
+		t.Errorf("Expected POST not to be retried, got %d attempts", calls)
+	}
+}
+
+func TestNoRetryForUpload(t *testing.T) {
+	calls := 0
+	client := newRetryAllMethodsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, true)
+
+	if _, err := client.UploadArtifact("default", "job.jar", strings.NewReader("jar bytes")); err == nil {
+		t.Error("Expected error for failed upload")
+	}
+	if calls != 1 {
+		t.Errorf("Expected upload not to be retried, got %d attempts", calls)
+	}
+}
+
+func TestNoRetryForClientErrors(t *testing.T) {
+	calls := 0
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	if _, err := client.GetNamespace("missing"); err == nil {
+		t.Error("Expected error for 404")
+	}
+	if calls != 1 {
+		t.Errorf("Expected 404 not to be retried, got %d attempts", calls)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{"", 0},
+		{"2", 2 * time.Second},
+		{"not-a-date", 0},
+		{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0},
+	}
+
+	for _, tt := range tests {
+		client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if tt.header != "" {
+				w.Header().Set("Retry-After", tt.header)
+			}
+		})
+		resp, err := client.httpClient.R().Get("/")
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		got, err := retryAfter(nil, resp)
+		if err != nil {
+			t.Fatalf("retryAfter(%q) returned error: %v", tt.header, err)
+		}
+		if got != tt.want {
+			t.Errorf("retryAfter(%q): expected %v, got %v", tt.header, tt.want, got)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -62,8 +63,14 @@ type SavepointCreationRequest struct {
 
 // ListSavepoints lists all savepoints in a namespace
 func (c *Client) ListSavepoints(namespace string) (*SavepointList, error) {
+	return c.ListSavepointsContext(context.Background(), namespace)
+}
+
+// ListSavepointsContext is like ListSavepoints but carries ctx through the request
+func (c *Client) ListSavepointsContext(ctx context.Context, namespace string) (*SavepointList, error) {
 	var result SavepointList
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetResult(&result).
 		Get(fmt.Sprintf("/api/v1/namespaces/%s/savepoints", namespace))
 
@@ -74,10 +81,44 @@ func (c *Client) ListSavepoints(namespace string) (*SavepointList, error) {
 	return &result, nil
 }
 
+// ListDeploymentSavepoints lists the savepoints that belong to a deployment
+func (c *Client) ListDeploymentSavepoints(namespace, deploymentID string) ([]Savepoint, error) {
+	return c.ListDeploymentSavepointsContext(context.Background(), namespace, deploymentID)
+}
+
+// ListDeploymentSavepointsContext is like ListDeploymentSavepoints but carries ctx through the request
+func (c *Client) ListDeploymentSavepointsContext(ctx context.Context, namespace, deploymentID string) ([]Savepoint, error) {
+	var result SavepointList
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetQueryParam("deploymentId", deploymentID).
+		SetResult(&result).
+		Get(fmt.Sprintf("/api/v1/namespaces/%s/savepoints", namespace))
+
+	if err := handleResponse(resp, err); err != nil {
+		return nil, err
+	}
+
+	// Filter client-side as well, in case the server ignores the query parameter
+	var savepoints []Savepoint
+	for _, sp := range result.Items {
+		if sp.Spec.DeploymentID == deploymentID {
+			savepoints = append(savepoints, sp)
+		}
+	}
+	return savepoints, nil
+}
+
 // GetSavepoint gets a savepoint by ID
 func (c *Client) GetSavepoint(namespace, savepointID string) (*Savepoint, error) {
+	return c.GetSavepointContext(context.Background(), namespace, savepointID)
+}
+
+// GetSavepointContext is like GetSavepoint but carries ctx through the request
+func (c *Client) GetSavepointContext(ctx context.Context, namespace, savepointID string) (*Savepoint, error) {
 	var result Savepoint
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetResult(&result).
 		Get(fmt.Sprintf("/api/v1/namespaces/%s/savepoints/%s", namespace, savepointID))
 
@@ -90,8 +131,14 @@ func (c *Client) GetSavepoint(namespace, savepointID string) (*Savepoint, error)
 
 // CreateSavepoint creates a new savepoint
 func (c *Client) CreateSavepoint(namespace string, savepoint *SavepointCreationRequest) (*Savepoint, error) {
+	return c.CreateSavepointContext(context.Background(), namespace, savepoint)
+}
+
+// CreateSavepointContext is like CreateSavepoint but carries ctx through the request
+func (c *Client) CreateSavepointContext(ctx context.Context, namespace string, savepoint *SavepointCreationRequest) (*Savepoint, error) {
 	var result Savepoint
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetBody(savepoint).
 		SetResult(&result).
 		Post(fmt.Sprintf("/api/v1/namespaces/%s/savepoints", namespace))
@@ -105,7 +152,13 @@ func (c *Client) CreateSavepoint(namespace string, savepoint *SavepointCreationR
 
 // DeleteSavepoint deletes a savepoint
 func (c *Client) DeleteSavepoint(namespace, savepointID string) error {
+	return c.DeleteSavepointContext(context.Background(), namespace, savepointID)
+}
+
+// DeleteSavepointContext is like DeleteSavepoint but carries ctx through the request
+func (c *Client) DeleteSavepointContext(ctx context.Context, namespace, savepointID string) error {
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		Delete(fmt.Sprintf("/api/v1/namespaces/%s/savepoints/%s", namespace, savepointID))
 
 	return handleResponse(resp, err)
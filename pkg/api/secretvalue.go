@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -13,8 +14,14 @@ type SecretValueList struct {
 
 // ListSecretValues lists all secret values in a namespace
 func (c *Client) ListSecretValues(namespace string) (*SecretValueList, error) {
+	return c.ListSecretValuesContext(context.Background(), namespace)
+}
+
+// ListSecretValuesContext is like ListSecretValues but carries ctx through the request
+func (c *Client) ListSecretValuesContext(ctx context.Context, namespace string) (*SecretValueList, error) {
 	var result SecretValueList
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetResult(&result).
 		Get(fmt.Sprintf("/api/v1/namespaces/%s/secret-values", namespace))
 
@@ -27,8 +34,14 @@ func (c *Client) ListSecretValues(namespace string) (*SecretValueList, error) {
 
 // GetSecretValue gets a secret value by name
 func (c *Client) GetSecretValue(namespace, name string) (*SecretValue, error) {
+	return c.GetSecretValueContext(context.Background(), namespace, name)
+}
+
+// GetSecretValueContext is like GetSecretValue but carries ctx through the request
+func (c *Client) GetSecretValueContext(ctx context.Context, namespace, name string) (*SecretValue, error) {
 	var result SecretValue
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetResult(&result).
 		Get(fmt.Sprintf("/api/v1/namespaces/%s/secret-values/%s", namespace, name))
 
@@ -41,8 +54,14 @@ func (c *Client) GetSecretValue(namespace, name string) (*SecretValue, error) {
 
 // CreateSecretValue creates a new secret value
 func (c *Client) CreateSecretValue(namespace string, secretValue *SecretValue) (*SecretValue, error) {
+	return c.CreateSecretValueContext(context.Background(), namespace, secretValue)
+}
+
+// CreateSecretValueContext is like CreateSecretValue but carries ctx through the request
+func (c *Client) CreateSecretValueContext(ctx context.Context, namespace string, secretValue *SecretValue) (*SecretValue, error) {
 	var result SecretValue
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetBody(secretValue).
 		SetResult(&result).
 		Post(fmt.Sprintf("/api/v1/namespaces/%s/secret-values", namespace))
@@ -56,8 +75,14 @@ func (c *Client) CreateSecretValue(namespace string, secretValue *SecretValue) (
 
 // UpdateSecretValue updates an existing secret value (PUT)
 func (c *Client) UpdateSecretValue(namespace, name string, secretValue *SecretValue) (*SecretValue, error) {
+	return c.UpdateSecretValueContext(context.Background(), namespace, name, secretValue)
+}
+
+// UpdateSecretValueContext is like UpdateSecretValue but carries ctx through the request
+func (c *Client) UpdateSecretValueContext(ctx context.Context, namespace, name string, secretValue *SecretValue) (*SecretValue, error) {
 	var result SecretValue
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetBody(secretValue).
 		SetResult(&result).
 		Put(fmt.Sprintf("/api/v1/namespaces/%s/secret-values/%s", namespace, name))
@@ -71,7 +96,13 @@ func (c *Client) UpdateSecretValue(namespace, name string, secretValue *SecretVa
 
 // DeleteSecretValue deletes a secret value
 func (c *Client) DeleteSecretValue(namespace, name string) error {
+	return c.DeleteSecretValueContext(context.Background(), namespace, name)
+}
+
+// DeleteSecretValueContext is like DeleteSecretValue but carries ctx through the request
+func (c *Client) DeleteSecretValueContext(ctx context.Context, namespace, name string) error {
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		Delete(fmt.Sprintf("/api/v1/namespaces/%s/secret-values/%s", namespace, name))
 
 	return handleResponse(resp, err)
@@ -1,15 +1,14 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
 
-// NOTE: Session endpoints do not exist in VVP API.
-// The endpoint /api/v1/namespaces/{ns}/sessions returns 404/500 errors.
-// Sessions are called "session clusters" in VVP and are referenced in deployments
-// via the sessionClusterName field, but there's no standalone API for managing them.
-// These functions are kept for reference but will not work.
+// Sessions are served under /api/v1/namespaces/{ns}/sessions. Not every VVP
+// version provides this endpoint; those that do not answer 404, and SQL work
+// is then done on session clusters (see SessionCluster).
 
 // Session represents a VVP session (SQL session)
 type Session struct {
@@ -54,10 +53,15 @@ type SessionList struct {
 }
 
 // ListSessions lists all sessions in a namespace
-// WARNING: This endpoint does not exist in VVP and will return 404 errors
 func (c *Client) ListSessions(namespace string) (*SessionList, error) {
+	return c.ListSessionsContext(context.Background(), namespace)
+}
+
+// ListSessionsContext is like ListSessions but carries ctx through the request
+func (c *Client) ListSessionsContext(ctx context.Context, namespace string) (*SessionList, error) {
 	var result SessionList
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetResult(&result).
 		Get(fmt.Sprintf("/api/v1/namespaces/%s/sessions", namespace))
 
@@ -69,10 +73,15 @@ func (c *Client) ListSessions(namespace string) (*SessionList, error) {
 }
 
 // GetSession gets a session by name
-// WARNING: This endpoint does not exist in VVP and will return 404 errors
 func (c *Client) GetSession(namespace, name string) (*Session, error) {
+	return c.GetSessionContext(context.Background(), namespace, name)
+}
+
+// GetSessionContext is like GetSession but carries ctx through the request
+func (c *Client) GetSessionContext(ctx context.Context, namespace, name string) (*Session, error) {
 	var result Session
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetResult(&result).
 		Get(fmt.Sprintf("/api/v1/namespaces/%s/sessions/%s", namespace, name))
 
@@ -84,10 +93,15 @@ func (c *Client) GetSession(namespace, name string) (*Session, error) {
 }
 
 // CreateSession creates a new session
-// WARNING: This endpoint does not exist in VVP and will return 500 errors
 func (c *Client) CreateSession(namespace string, session *Session) (*Session, error) {
+	return c.CreateSessionContext(context.Background(), namespace, session)
+}
+
+// CreateSessionContext is like CreateSession but carries ctx through the request
+func (c *Client) CreateSessionContext(ctx context.Context, namespace string, session *Session) (*Session, error) {
 	var result Session
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetBody(session).
 		SetResult(&result).
 		Post(fmt.Sprintf("/api/v1/namespaces/%s/sessions", namespace))
@@ -100,10 +114,15 @@ func (c *Client) CreateSession(namespace string, session *Session) (*Session, er
 }
 
 // UpdateSession updates an existing session
-// WARNING: This endpoint does not exist in VVP and will return 500 errors
 func (c *Client) UpdateSession(namespace, name string, session *Session) (*Session, error) {
+	return c.UpdateSessionContext(context.Background(), namespace, name, session)
+}
+
+// UpdateSessionContext is like UpdateSession but carries ctx through the request
+func (c *Client) UpdateSessionContext(ctx context.Context, namespace, name string, session *Session) (*Session, error) {
 	var result Session
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetBody(session).
 		SetResult(&result).
 		Put(fmt.Sprintf("/api/v1/namespaces/%s/sessions/%s", namespace, name))
@@ -116,9 +135,14 @@ func (c *Client) UpdateSession(namespace, name string, session *Session) (*Sessi
 }
 
 // DeleteSession deletes a session
-// WARNING: This endpoint does not exist in VVP and will return 404 errors
 func (c *Client) DeleteSession(namespace, name string) error {
+	return c.DeleteSessionContext(context.Background(), namespace, name)
+}
+
+// DeleteSessionContext is like DeleteSession but carries ctx through the request
+func (c *Client) DeleteSessionContext(ctx context.Context, namespace, name string) error {
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		Delete(fmt.Sprintf("/api/v1/namespaces/%s/sessions/%s", namespace, name))
 
 	return handleResponse(resp, err)
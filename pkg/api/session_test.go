@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestSessionParsing(t *testing.T) {
+	yamlContent := `
+metadata:
+  name: test-session
+  namespace: default
+  labels:
+    team: analytics
+spec:
+  deploymentTargetId: 5a3f2c1e-1d6e-4a97-bd64-590f20b605e7
+  flinkVersion: "1.20"
+  sessionClusterResourceProfile:
+    cpu: "2"
+    memory: 4g
+  flinkConfiguration:
+    taskmanager.numberOfTaskSlots: "4"
+status:
+  state: RUNNING
+`
+
+	var session Session
+	err := yaml.Unmarshal([]byte(yamlContent), &session)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	// Test metadata
+	if session.Metadata.Name != "test-session" {
+		t.Errorf("Expected name 'test-session', got '%s'", session.Metadata.Name)
+	}
+	if session.Metadata.Namespace != "default" {
+		t.Errorf("Expected namespace 'default', got '%s'", session.Metadata.Namespace)
+	}
+	if session.Metadata.Labels["team"] != "analytics" {
+		t.Errorf("Expected label 'team' to be 'analytics'")
+	}
+
+	// Test spec
+	if session.Spec.DeploymentTargetID != "5a3f2c1e-1d6e-4a97-bd64-590f20b605e7" {
+		t.Errorf("Expected deploymentTargetId '5a3f2c1e-1d6e-4a97-bd64-590f20b605e7', got '%s'", session.Spec.DeploymentTargetID)
+	}
+	if session.Spec.FlinkVersion != "1.20" {
+		t.Errorf("Expected flinkVersion '1.20', got '%s'", session.Spec.FlinkVersion)
+	}
+	if session.Spec.FlinkConfiguration["taskmanager.numberOfTaskSlots"] != "4" {
+		t.Errorf("Expected taskSlots '4', got '%s'", session.Spec.FlinkConfiguration["taskmanager.numberOfTaskSlots"])
+	}
+
+	// Test resource profile
+	profile := session.Spec.SessionClusterResourceProfile
+	if profile.CPU != "2" {
+		t.Errorf("Expected cpu '2', got '%s'", profile.CPU)
+	}
+	if profile.Memory != "4g" {
+		t.Errorf("Expected memory '4g', got '%s'", profile.Memory)
+	}
+
+	// Test status
+	if session.Status.State != "RUNNING" {
+		t.Errorf("Expected state 'RUNNING', got '%s'", session.Status.State)
+	}
+}
+
+func TestSessionListParsing(t *testing.T) {
+	jsonContent := `{
+  "items": [
+    {
+      "metadata": {"name": "session1", "namespace": "default"},
+      "spec": {
+        "deploymentTargetId": "target-1",
+        "flinkVersion": "1.20",
+        "sessionClusterResourceProfile": {"cpu": "1", "memory": "2g"}
+      },
+      "status": {"state": "RUNNING"}
+    },
+    {
+      "metadata": {"name": "session2", "namespace": "default"},
+      "spec": {"deploymentTargetId": "target-2", "flinkVersion": "1.19"},
+      "status": {"state": "STOPPED"}
+    }
+  ]
+}`
+
+	var sessionList SessionList
+	err := json.Unmarshal([]byte(jsonContent), &sessionList)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+
+	if len(sessionList.Items) != 2 {
+		t.Fatalf("Expected 2 sessions, got %d", len(sessionList.Items))
+	}
+
+	// Test first session
+	if sessionList.Items[0].Metadata.Name != "session1" {
+		t.Errorf("Expected first session name 'session1', got '%s'", sessionList.Items[0].Metadata.Name)
+	}
+	if sessionList.Items[0].Spec.SessionClusterResourceProfile.Memory != "2g" {
+		t.Errorf("Expected first session memory '2g', got '%s'", sessionList.Items[0].Spec.SessionClusterResourceProfile.Memory)
+	}
+
+	// Test second session
+	if sessionList.Items[1].Spec.DeploymentTargetID != "target-2" {
+		t.Errorf("Expected second session deploymentTargetId 'target-2', got '%s'", sessionList.Items[1].Spec.DeploymentTargetID)
+	}
+	if sessionList.Items[1].Status.State != "STOPPED" {
+		t.Errorf("Expected second session state 'STOPPED', got '%s'", sessionList.Items[1].Status.State)
+	}
+}
+
+func TestSessionYAMLRoundTrip(t *testing.T) {
+	session := Session{
+		Metadata: SessionMetadata{Name: "minimal", Namespace: "default"},
+		Spec:     SessionSpec{FlinkVersion: "1.20"},
+	}
+
+	data, err := yaml.Marshal(session)
+	if err != nil {
+		t.Fatalf("Failed to marshal session: %v", err)
+	}
+
+	var roundTrip Session
+	if err := yaml.Unmarshal(data, &roundTrip); err != nil {
+		t.Fatalf("Failed to unmarshal session: %v", err)
+	}
+	if roundTrip.Metadata.Name != "minimal" || roundTrip.Spec.FlinkVersion != "1.20" {
+		t.Errorf("Round trip lost fields: %+v", roundTrip)
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -69,8 +70,14 @@ type SessionClusterList struct {
 
 // ListSessionClusters lists all session clusters in a namespace
 func (c *Client) ListSessionClusters(namespace string) (*SessionClusterList, error) {
+	return c.ListSessionClustersContext(context.Background(), namespace)
+}
+
+// ListSessionClustersContext is like ListSessionClusters but carries ctx through the request
+func (c *Client) ListSessionClustersContext(ctx context.Context, namespace string) (*SessionClusterList, error) {
 	var result SessionClusterList
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetResult(&result).
 		Get(fmt.Sprintf("/api/v1/namespaces/%s/sessionclusters", namespace))
 
@@ -83,8 +90,14 @@ func (c *Client) ListSessionClusters(namespace string) (*SessionClusterList, err
 
 // GetSessionCluster gets a session cluster by name
 func (c *Client) GetSessionCluster(namespace, name string) (*SessionCluster, error) {
+	return c.GetSessionClusterContext(context.Background(), namespace, name)
+}
+
+// GetSessionClusterContext is like GetSessionCluster but carries ctx through the request
+func (c *Client) GetSessionClusterContext(ctx context.Context, namespace, name string) (*SessionCluster, error) {
 	var result SessionCluster
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetResult(&result).
 		Get(fmt.Sprintf("/api/v1/namespaces/%s/sessionclusters/%s", namespace, name))
 
@@ -97,8 +110,14 @@ func (c *Client) GetSessionCluster(namespace, name string) (*SessionCluster, err
 
 // CreateSessionCluster creates a new session cluster
 func (c *Client) CreateSessionCluster(namespace string, sessionCluster *SessionCluster) (*SessionCluster, error) {
+	return c.CreateSessionClusterContext(context.Background(), namespace, sessionCluster)
+}
+
+// CreateSessionClusterContext is like CreateSessionCluster but carries ctx through the request
+func (c *Client) CreateSessionClusterContext(ctx context.Context, namespace string, sessionCluster *SessionCluster) (*SessionCluster, error) {
 	var result SessionCluster
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetBody(sessionCluster).
 		SetResult(&result).
 		Post(fmt.Sprintf("/api/v1/namespaces/%s/sessionclusters", namespace))
@@ -112,8 +131,14 @@ func (c *Client) CreateSessionCluster(namespace string, sessionCluster *SessionC
 
 // UpdateSessionCluster updates an existing session cluster (PATCH)
 func (c *Client) UpdateSessionCluster(namespace, name string, sessionCluster *SessionCluster) (*SessionCluster, error) {
+	return c.UpdateSessionClusterContext(context.Background(), namespace, name, sessionCluster)
+}
+
+// UpdateSessionClusterContext is like UpdateSessionCluster but carries ctx through the request
+func (c *Client) UpdateSessionClusterContext(ctx context.Context, namespace, name string, sessionCluster *SessionCluster) (*SessionCluster, error) {
 	var result SessionCluster
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetBody(sessionCluster).
 		SetResult(&result).
 		Patch(fmt.Sprintf("/api/v1/namespaces/%s/sessionclusters/%s", namespace, name))
@@ -127,8 +152,14 @@ func (c *Client) UpdateSessionCluster(namespace, name string, sessionCluster *Se
 
 // UpsertSessionCluster creates or replaces a session cluster (PUT)
 func (c *Client) UpsertSessionCluster(namespace, name string, sessionCluster *SessionCluster) (*SessionCluster, error) {
+	return c.UpsertSessionClusterContext(context.Background(), namespace, name, sessionCluster)
+}
+
+// UpsertSessionClusterContext is like UpsertSessionCluster but carries ctx through the request
+func (c *Client) UpsertSessionClusterContext(ctx context.Context, namespace, name string, sessionCluster *SessionCluster) (*SessionCluster, error) {
 	var result SessionCluster
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetBody(sessionCluster).
 		SetResult(&result).
 		Put(fmt.Sprintf("/api/v1/namespaces/%s/sessionclusters/%s", namespace, name))
@@ -142,8 +173,36 @@ func (c *Client) UpsertSessionCluster(namespace, name string, sessionCluster *Se
 
 // DeleteSessionCluster deletes a session cluster
 func (c *Client) DeleteSessionCluster(namespace, name string) error {
+	return c.DeleteSessionClusterContext(context.Background(), namespace, name)
+}
+
+// DeleteSessionClusterContext is like DeleteSessionCluster but carries ctx through the request
+func (c *Client) DeleteSessionClusterContext(ctx context.Context, namespace, name string) error {
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		Delete(fmt.Sprintf("/api/v1/namespaces/%s/sessionclusters/%s", namespace, name))
 
 	return handleResponse(resp, err)
 }
+
+// ListSessionClusterDeployments lists the deployments of a namespace that run
+// on the given session cluster, i.e. whose spec.sessionClusterName matches
+func (c *Client) ListSessionClusterDeployments(namespace, name string) ([]Deployment, error) {
+	return c.ListSessionClusterDeploymentsContext(context.Background(), namespace, name)
+}
+
+// ListSessionClusterDeploymentsContext is like ListSessionClusterDeployments but carries ctx through the request
+func (c *Client) ListSessionClusterDeploymentsContext(ctx context.Context, namespace, name string) ([]Deployment, error) {
+	list, err := c.ListDeploymentsContext(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var deployments []Deployment
+	for _, item := range list.Items {
+		if item.Deployment.Spec.SessionClusterName == name {
+			deployments = append(deployments, item.Deployment)
+		}
+	}
+	return deployments, nil
+}
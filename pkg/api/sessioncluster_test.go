@@ -1,6 +1,7 @@
 package api
 
 import (
+	"net/http"
 	"testing"
 
 	"gopkg.in/yaml.v3"
@@ -205,3 +206,29 @@ spec:
 
 	t.Log("SessionCluster with Kubernetes spec parsing test passed!")
 }
+
+func TestListSessionClusterDeployments(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/default/deployments/with-cr" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [
+			{"deployment": {"metadata": {"name": "sql-1"}, "spec": {"state": "RUNNING", "sessionClusterName": "my-sql-session"}, "status": {"state": "RUNNING"}}},
+			{"deployment": {"metadata": {"name": "sql-2"}, "spec": {"state": "RUNNING", "sessionClusterName": "other-session"}, "status": {"state": "RUNNING"}}},
+			{"deployment": {"metadata": {"name": "jar-1"}, "spec": {"state": "RUNNING", "deploymentTargetName": "k8s"}, "status": {"state": "RUNNING"}}},
+			{"deployment": {"metadata": {"name": "sql-3"}, "spec": {"state": "CANCELLED", "sessionClusterName": "my-sql-session"}, "status": {"state": "CANCELLED"}}}
+		]}`))
+	})
+
+	deployments, err := client.ListSessionClusterDeployments("default", "my-sql-session")
+	if err != nil {
+		t.Fatalf("Failed to list session cluster deployments: %v", err)
+	}
+	if len(deployments) != 2 {
+		t.Fatalf("Expected 2 deployments, got %d", len(deployments))
+	}
+	if deployments[0].Metadata.Name != "sql-1" || deployments[1].Metadata.Name != "sql-3" {
+		t.Errorf("Expected sql-1 and sql-3, got %s and %s", deployments[0].Metadata.Name, deployments[1].Metadata.Name)
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -47,8 +48,14 @@ type ResourceUsage struct {
 
 // GetStatus retrieves the platform status
 func (c *Client) GetStatus() (*Status, error) {
+	return c.GetStatusContext(context.Background())
+}
+
+// GetStatusContext is like GetStatus but carries ctx through the request
+func (c *Client) GetStatusContext(ctx context.Context) (*Status, error) {
 	var result Status
 	resp, err := c.httpClient.R().
+		SetContext(ctx).
 		SetResult(&result).
 		Get("/api/v1/status")
 
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"mcolomerc/vvp2cli/pkg/config"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// TLS modes reported by ConnectionInfo
+const (
+	TLSModeNone     = "none"     // plain HTTP
+	TLSModeInsecure = "insecure" // certificate verification disabled
+	TLSModeSystem   = "system"   // server verified against the system roots
+	TLSModeCustomCA = "custom-ca"
+	TLSModeMutual   = "mutual"
+)
+
+// ConnectionInfo describes how the client reaches the API
+type ConnectionInfo struct {
+	URL     string `json:"url" yaml:"url"`
+	TLSMode string `json:"tlsMode" yaml:"tlsMode"`
+	CAFile  string `json:"caFile,omitempty" yaml:"caFile,omitempty"`
+	Proxy   string `json:"proxy,omitempty" yaml:"proxy,omitempty"`
+}
+
+// DescribeConnection reports the TLS mode and proxy used for cfg
+func DescribeConnection(cfg *config.Config) ConnectionInfo {
+	info := ConnectionInfo{URL: cfg.GetAPIURL(), TLSMode: tlsMode(cfg)}
+	if info.TLSMode == TLSModeCustomCA || info.TLSMode == TLSModeMutual {
+		info.CAFile = cfg.API.CAFile
+	}
+
+	if target, err := url.Parse(cfg.GetAPIURL()); err == nil {
+		if proxy, err := proxyFunc(cfg)(target); err == nil && proxy != nil {
+			info.Proxy = proxy.Redacted()
+		}
+	}
+	return info
+}
+
+// tlsMode classifies the TLS settings in cfg
+func tlsMode(cfg *config.Config) string {
+	if u, err := url.Parse(cfg.GetAPIURL()); err == nil && u.Scheme == "http" {
+		return TLSModeNone
+	}
+	switch {
+	case cfg.IsInsecure():
+		return TLSModeInsecure
+	case cfg.API.ClientCert != "":
+		return TLSModeMutual
+	case cfg.API.CAFile != "":
+		return TLSModeCustomCA
+	}
+	return TLSModeSystem
+}
+
+// NewHTTPClient returns a plain HTTP client that reaches the network like the
+// API client: with the TLS (CA file, client certificate, insecure) and proxy
+// settings of cfg and the API request timeout. It is meant for requests to
+// other services on the API's behalf, such as an OIDC provider.
+func NewHTTPClient(cfg *config.Config) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if err := configureTransport(transport, cfg); err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: transport, Timeout: requestTimeout}, nil
+}
+
+// configureTransport applies the TLS and proxy settings from cfg to transport
+func configureTransport(transport *http.Transport, cfg *config.Config) error {
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	proxy := proxyFunc(cfg)
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}
+	return nil
+}
+
+// newTLSConfig builds the client TLS configuration. The CA bundle is added to
+// the system roots rather than replacing them.
+func newTLSConfig(cfg *config.Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if cfg.IsInsecure() {
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	if cfg.API.CAFile != "" {
+		pem, err := os.ReadFile(config.ExpandHome(cfg.API.CAFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in CA file %s", cfg.API.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.API.ClientCert != "" || cfg.API.ClientKey != "" {
+		if cfg.API.ClientCert == "" || cfg.API.ClientKey == "" {
+			return nil, fmt.Errorf("api.clientCert and api.clientKey must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(config.ExpandHome(cfg.API.ClientCert), config.ExpandHome(cfg.API.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// proxyFunc selects the proxy for a request URL. api.proxy and api.noProxy
+// take precedence over HTTPS_PROXY, HTTP_PROXY and NO_PROXY.
+func proxyFunc(cfg *config.Config) func(*url.URL) (*url.URL, error) {
+	proxyConfig := httpproxy.FromEnvironment()
+	if cfg.API.Proxy != "" {
+		proxyConfig.HTTPProxy = cfg.API.Proxy
+		proxyConfig.HTTPSProxy = cfg.API.Proxy
+	}
+	if cfg.API.NoProxy != "" {
+		proxyConfig.NoProxy = cfg.API.NoProxy
+	}
+	return proxyConfig.ProxyFunc()
+}
@@ -0,0 +1,236 @@
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"mcolomerc/vvp2cli/pkg/config"
+)
+
+// writeSelfSigned writes a self-signed certificate and its key as PEM files
+func writeSelfSigned(t *testing.T, dir, name string) (certFile, keyFile string, cert *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ = x509.ParseCertificate(der)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	return certFile, keyFile, cert
+}
+
+// writeServerCA writes the certificate of a TLS test server as a CA bundle
+func writeServerCA(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return caFile
+}
+
+func TestCustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, NamespaceList{})
+	}))
+	defer server.Close()
+
+	// Without the CA the server certificate is rejected
+	client, err := NewClient(&config.Config{API: config.APIConfig{URL: server.URL}})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if _, err := client.ListNamespaces(); err == nil {
+		t.Fatal("Expected certificate verification to fail without the CA file")
+	}
+
+	cfg := &config.Config{API: config.APIConfig{URL: server.URL, CAFile: writeServerCA(t, server)}}
+	client, err = NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if _, err := client.ListNamespaces(); err != nil {
+		t.Errorf("Expected request to succeed with the CA file, got %v", err)
+	}
+	if mode := DescribeConnection(cfg).TLSMode; mode != TLSModeCustomCA {
+		t.Errorf("Expected TLS mode %s, got %s", TLSModeCustomCA, mode)
+	}
+}
+
+func TestOIDCUsesAPITransport(t *testing.T) {
+	// The OIDC provider shares the API server's custom CA
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			writeJSON(w, map[string]interface{}{"access_token": "oidc-token", "token_type": "Bearer", "expires_in": 3600})
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer oidc-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, NamespaceList{})
+	}))
+	defer server.Close()
+	t.Setenv("HOME", t.TempDir())
+
+	client, err := NewClient(&config.Config{API: config.APIConfig{
+		URL:    server.URL,
+		CAFile: writeServerCA(t, server),
+		OIDC:   &config.OIDCConfig{TokenEndpoint: server.URL + "/token", ClientID: "vvp2", ClientSecret: "secret"},
+	}})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if _, err := client.ListNamespaces(); err != nil {
+		t.Errorf("Expected the OIDC token request to trust the CA file, got %v", err)
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	certFile, keyFile, clientCert := writeSelfSigned(t, t.TempDir(), "vvp2")
+
+	var subject string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject = r.TLS.PeerCertificates[0].Subject.CommonName
+		writeJSON(w, NamespaceList{})
+	}))
+	pool := x509.NewCertPool()
+	pool.AddCert(clientCert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool}
+	server.StartTLS()
+	defer server.Close()
+
+	cfg := &config.Config{API: config.APIConfig{
+		URL:        server.URL,
+		CAFile:     writeServerCA(t, server),
+		ClientCert: certFile,
+		ClientKey:  keyFile,
+	}}
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if _, err := client.ListNamespaces(); err != nil {
+		t.Fatalf("Expected request to succeed with a client certificate, got %v", err)
+	}
+	if subject != "vvp2" {
+		t.Errorf("Expected client certificate 'vvp2', got '%s'", subject)
+	}
+	if mode := DescribeConnection(cfg).TLSMode; mode != TLSModeMutual {
+		t.Errorf("Expected TLS mode %s, got %s", TLSModeMutual, mode)
+	}
+}
+
+func TestInvalidTLSFiles(t *testing.T) {
+	dir := t.TempDir()
+	notPEM := filepath.Join(dir, "ca.pem")
+	os.WriteFile(notPEM, []byte("not a certificate"), 0600)
+	certFile, _, _ := writeSelfSigned(t, dir, "vvp2")
+
+	tests := []struct {
+		name string
+		api  config.APIConfig
+	}{
+		{"missing CA file", config.APIConfig{CAFile: filepath.Join(dir, "missing.pem")}},
+		{"CA file without certificates", config.APIConfig{CAFile: notPEM}},
+		{"client certificate without key", config.APIConfig{ClientCert: certFile}},
+		{"key does not match", config.APIConfig{ClientCert: certFile, ClientKey: notPEM}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.api.URL = "https://vvp.example.com"
+			if _, err := NewClient(&config.Config{API: tt.api}); err == nil {
+				t.Error("Expected NewClient to fail")
+			}
+		})
+	}
+}
+
+func TestTLSMode(t *testing.T) {
+	tests := []struct {
+		api  config.APIConfig
+		want string
+	}{
+		{config.APIConfig{URL: "http://vvp.local"}, TLSModeNone},
+		{config.APIConfig{URL: "https://vvp.local"}, TLSModeSystem},
+		{config.APIConfig{URL: "https://vvp.local", Insecure: true, CAFile: "ca.pem"}, TLSModeInsecure},
+		{config.APIConfig{URL: "https://vvp.local", CAFile: "ca.pem"}, TLSModeCustomCA},
+		{config.APIConfig{URL: "https://vvp.local", ClientCert: "c.pem", ClientKey: "k.pem"}, TLSModeMutual},
+	}
+
+	for _, tt := range tests {
+		if got := tlsMode(&config.Config{API: tt.api}); got != tt.want {
+			t.Errorf("tlsMode(%+v) = %s, want %s", tt.api, got, tt.want)
+		}
+	}
+}
+
+func TestProxyFunc(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "http://env-proxy:3128")
+	t.Setenv("NO_PROXY", "")
+
+	target, _ := url.Parse("https://vvp.example.com/api")
+	internal, _ := url.Parse("https://vvp.internal/api")
+
+	tests := []struct {
+		name   string
+		api    config.APIConfig
+		target *url.URL
+		want   string
+	}{
+		{"environment", config.APIConfig{}, target, "http://env-proxy:3128"},
+		{"configured proxy wins", config.APIConfig{Proxy: "http://proxy:8080"}, target, "http://proxy:8080"},
+		{"no proxy match", config.APIConfig{Proxy: "http://proxy:8080", NoProxy: ".internal"}, internal, ""},
+		{"no proxy miss", config.APIConfig{Proxy: "http://proxy:8080", NoProxy: ".internal"}, target, "http://proxy:8080"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxy, err := proxyFunc(&config.Config{API: tt.api})(tt.target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := ""
+			if proxy != nil {
+				got = proxy.String()
+			}
+			if got != tt.want {
+				t.Errorf("Expected proxy '%s', got '%s'", tt.want, got)
+			}
+		})
+	}
+}
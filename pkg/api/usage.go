@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/csv"
 	"fmt"
 	"strings"
@@ -54,7 +55,12 @@ func (r *ResourceUsageReport) ParseCSV() ([]map[string]string, error) {
 }
 
 func (c *Client) GetResourceUsageReport(from, to string) (*ResourceUsageReport, error) {
-       req := c.httpClient.R()
+	return c.GetResourceUsageReportContext(context.Background(), from, to)
+}
+
+// GetResourceUsageReportContext is like GetResourceUsageReport but carries ctx through the request
+func (c *Client) GetResourceUsageReportContext(ctx context.Context, from, to string) (*ResourceUsageReport, error) {
+       req := c.httpClient.R().SetContext(ctx)
        if from != "" {
               req.SetQueryParam("from", from)
        }
@@ -0,0 +1,232 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultPollInterval is how often the wait helpers poll resource status
+const DefaultPollInterval = 2 * time.Second
+
+// ErrWaitTimeout is returned when a wait helper runs out of time
+var ErrWaitTimeout = errors.New("timed out waiting for the condition")
+
+// WaitOptions controls how long and how often the wait helpers poll.
+// A zero Timeout waits indefinitely; a zero Interval uses DefaultPollInterval.
+type WaitOptions struct {
+	Timeout  time.Duration
+	Interval time.Duration
+}
+
+// poll calls check until it reports done, returns an error, the timeout
+// expires or ctx is cancelled
+func poll(ctx context.Context, opts WaitOptions, check func(context.Context) (bool, error)) error {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	var deadline time.Time
+	if opts.Timeout > 0 {
+		deadline = time.Now().Add(opts.Timeout)
+	}
+
+	for {
+		done, err := check(ctx)
+		if err != nil || done {
+			return err
+		}
+
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return ErrWaitTimeout
+			}
+			if remaining < interval {
+				interval = remaining
+			}
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// WaitForDeployment polls a deployment until cond reports true or returns an error
+func (c *Client) WaitForDeployment(namespace, name string, opts WaitOptions, cond func(*Deployment) (bool, error)) (*Deployment, error) {
+	return c.WaitForDeploymentContext(context.Background(), namespace, name, opts, cond)
+}
+
+// WaitForDeploymentContext is like WaitForDeployment but stops waiting when ctx is cancelled
+func (c *Client) WaitForDeploymentContext(ctx context.Context, namespace, name string, opts WaitOptions, cond func(*Deployment) (bool, error)) (*Deployment, error) {
+	var current *Deployment
+	err := poll(ctx, opts, func(ctx context.Context) (bool, error) {
+		deployment, err := c.GetDeploymentContext(ctx, namespace, name)
+		if err != nil {
+			return false, err
+		}
+		current = deployment
+		return cond(deployment)
+	})
+	if errors.Is(err, ErrWaitTimeout) {
+		return current, fmt.Errorf("deployment %s did not reach the expected state (last state: %s): %w", name, deploymentState(current), err)
+	}
+	return current, err
+}
+
+// WaitForDeploymentState polls a deployment until status.state equals state.
+// It fails fast if the deployment ends up FAILED while waiting for another state.
+func (c *Client) WaitForDeploymentState(namespace, name, state string, opts WaitOptions) (*Deployment, error) {
+	return c.WaitForDeploymentStateContext(context.Background(), namespace, name, state, opts)
+}
+
+// WaitForDeploymentStateContext is like WaitForDeploymentState but stops waiting when ctx is cancelled
+func (c *Client) WaitForDeploymentStateContext(ctx context.Context, namespace, name, state string, opts WaitOptions) (*Deployment, error) {
+	return c.WaitForDeploymentContext(ctx, namespace, name, opts, func(d *Deployment) (bool, error) {
+		current := deploymentState(d)
+		if current == state {
+			return true, nil
+		}
+		if current == "FAILED" {
+			return false, fmt.Errorf("deployment %s is in FAILED state", name)
+		}
+		return false, nil
+	})
+}
+
+// WaitForDeploymentStopped polls a deployment until its job has left the
+// RUNNING and TRANSITIONING states, e.g. after cancelling it.
+func (c *Client) WaitForDeploymentStopped(namespace, name string, opts WaitOptions) (*Deployment, error) {
+	return c.WaitForDeploymentStoppedContext(context.Background(), namespace, name, opts)
+}
+
+// WaitForDeploymentStoppedContext is like WaitForDeploymentStopped but stops waiting when ctx is cancelled
+func (c *Client) WaitForDeploymentStoppedContext(ctx context.Context, namespace, name string, opts WaitOptions) (*Deployment, error) {
+	return c.WaitForDeploymentContext(ctx, namespace, name, opts, func(d *Deployment) (bool, error) {
+		switch deploymentState(d) {
+		case "RUNNING", "TRANSITIONING":
+			return false, nil
+		}
+		return true, nil
+	})
+}
+
+// WaitForSessionCluster polls a session cluster until cond reports true or returns an error
+func (c *Client) WaitForSessionCluster(namespace, name string, opts WaitOptions, cond func(*SessionCluster) (bool, error)) (*SessionCluster, error) {
+	return c.WaitForSessionClusterContext(context.Background(), namespace, name, opts, cond)
+}
+
+// WaitForSessionClusterContext is like WaitForSessionCluster but stops waiting when ctx is cancelled
+func (c *Client) WaitForSessionClusterContext(ctx context.Context, namespace, name string, opts WaitOptions, cond func(*SessionCluster) (bool, error)) (*SessionCluster, error) {
+	var current *SessionCluster
+	err := poll(ctx, opts, func(ctx context.Context) (bool, error) {
+		sessionCluster, err := c.GetSessionClusterContext(ctx, namespace, name)
+		if err != nil {
+			return false, err
+		}
+		current = sessionCluster
+		return cond(sessionCluster)
+	})
+	if errors.Is(err, ErrWaitTimeout) {
+		return current, fmt.Errorf("session cluster %s did not reach the expected state (last state: %s): %w", name, sessionClusterState(current), err)
+	}
+	return current, err
+}
+
+// WaitForSessionClusterState polls a session cluster until status.state equals state.
+// It fails fast, including the failure reason, if the cluster ends up FAILED.
+func (c *Client) WaitForSessionClusterState(namespace, name, state string, opts WaitOptions) (*SessionCluster, error) {
+	return c.WaitForSessionClusterStateContext(context.Background(), namespace, name, state, opts)
+}
+
+// WaitForSessionClusterStateContext is like WaitForSessionClusterState but stops waiting when ctx is cancelled
+func (c *Client) WaitForSessionClusterStateContext(ctx context.Context, namespace, name, state string, opts WaitOptions) (*SessionCluster, error) {
+	return c.WaitForSessionClusterContext(ctx, namespace, name, opts, func(sc *SessionCluster) (bool, error) {
+		switch sc.Status.State {
+		case state:
+			return true, nil
+		case "FAILED":
+			return false, SessionClusterFailedError(name, sc.Status.Failure)
+		}
+		return false, nil
+	})
+}
+
+// SessionClusterFailedError describes a FAILED session cluster, including the
+// failure reason and message if VVP reported them
+func SessionClusterFailedError(name string, failure *Failure) error {
+	return fmt.Errorf("session cluster %s is in FAILED state%s", name, failureDetails(failure))
+}
+
+// WaitForSavepoint polls a savepoint until it is COMPLETED, failing fast if it FAILED
+func (c *Client) WaitForSavepoint(namespace, savepointID string, opts WaitOptions) (*Savepoint, error) {
+	return c.WaitForSavepointContext(context.Background(), namespace, savepointID, opts)
+}
+
+// WaitForSavepointContext is like WaitForSavepoint but stops waiting when ctx is cancelled
+func (c *Client) WaitForSavepointContext(ctx context.Context, namespace, savepointID string, opts WaitOptions) (*Savepoint, error) {
+	var current *Savepoint
+	err := poll(ctx, opts, func(ctx context.Context) (bool, error) {
+		savepoint, err := c.GetSavepointContext(ctx, namespace, savepointID)
+		if err != nil {
+			return false, err
+		}
+		current = savepoint
+
+		switch savepoint.Status.State {
+		case "COMPLETED":
+			return true, nil
+		case "FAILED":
+			msg := ""
+			if savepoint.Status.Failed != nil {
+				msg = failureDetails(&Failure{Reason: savepoint.Status.Failed.Reason, Message: savepoint.Status.Failed.Message})
+			}
+			return false, fmt.Errorf("savepoint %s FAILED%s", savepointID, msg)
+		}
+		return false, nil
+	})
+	if errors.Is(err, ErrWaitTimeout) {
+		return current, fmt.Errorf("savepoint %s did not complete (last state: %s): %w", savepointID, current.Status.State, err)
+	}
+	return current, err
+}
+
+// deploymentState returns the observed state of a deployment, ignoring spec.state
+func deploymentState(d *Deployment) string {
+	if d == nil || d.Status == nil || d.Status.State == "" {
+		return "unknown"
+	}
+	return d.Status.State
+}
+
+// sessionClusterState returns the observed state of a session cluster
+func sessionClusterState(sc *SessionCluster) string {
+	if sc == nil || sc.Status.State == "" {
+		return "unknown"
+	}
+	if sc.Status.State == "FAILED" {
+		return sc.Status.State + failureDetails(sc.Status.Failure)
+	}
+	return sc.Status.State
+}
+
+// failureDetails formats a failure reason and message for error messages
+func failureDetails(f *Failure) string {
+	if f == nil || (f.Reason == "" && f.Message == "") {
+		return ""
+	}
+	if f.Reason == "" {
+		return ": " + f.Message
+	}
+	if f.Message == "" {
+		return ": " + f.Reason
+	}
+	return fmt.Sprintf(": %s: %s", f.Reason, f.Message)
+}
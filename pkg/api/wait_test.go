@@ -0,0 +1,169 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"mcolomerc/vvp2cli/pkg/config"
+)
+
+// newTestClient returns a client pointed at a test server
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&config.Config{API: config.APIConfig{URL: server.URL}})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return client
+}
+
+// writeJSON writes v as a JSON response body
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+var fastWait = WaitOptions{Timeout: 2 * time.Second, Interval: 10 * time.Millisecond}
+
+func TestWaitForDeploymentState(t *testing.T) {
+	states := []string{"TRANSITIONING", "TRANSITIONING", "RUNNING"}
+	calls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		state := states[min(calls, len(states)-1)]
+		calls++
+		writeJSON(w, DeploymentWithInfo{Deployment: Deployment{
+			Metadata: DeploymentMetadata{Name: "job"},
+			Status:   &DeploymentStatus{State: state},
+		}})
+	})
+
+	deployment, err := client.WaitForDeploymentState("default", "job", "RUNNING", fastWait)
+	if err != nil {
+		t.Fatalf("Expected deployment to reach RUNNING, got error: %v", err)
+	}
+	if deployment.Status.State != "RUNNING" {
+		t.Errorf("Expected state 'RUNNING', got '%s'", deployment.Status.State)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 polls, got %d", calls)
+	}
+}
+
+func TestWaitForDeploymentStateFailsFast(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, DeploymentWithInfo{Deployment: Deployment{Status: &DeploymentStatus{State: "FAILED"}}})
+	})
+
+	_, err := client.WaitForDeploymentState("default", "job", "RUNNING", fastWait)
+	if err == nil || !strings.Contains(err.Error(), "FAILED") {
+		t.Errorf("Expected FAILED error, got %v", err)
+	}
+}
+
+func TestWaitForDeploymentStateTimeout(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, DeploymentWithInfo{Deployment: Deployment{Status: &DeploymentStatus{State: "TRANSITIONING"}}})
+	})
+
+	opts := WaitOptions{Timeout: 50 * time.Millisecond, Interval: 10 * time.Millisecond}
+	_, err := client.WaitForDeploymentState("default", "job", "RUNNING", opts)
+	if !errors.Is(err, ErrWaitTimeout) {
+		t.Errorf("Expected ErrWaitTimeout, got %v", err)
+	}
+}
+
+func TestWaitForDeploymentStopped(t *testing.T) {
+	states := []string{"RUNNING", "TRANSITIONING", "CANCELLED"}
+	calls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		state := states[min(calls, len(states)-1)]
+		calls++
+		writeJSON(w, DeploymentWithInfo{Deployment: Deployment{Status: &DeploymentStatus{State: state}}})
+	})
+
+	deployment, err := client.WaitForDeploymentStopped("default", "job", fastWait)
+	if err != nil {
+		t.Fatalf("Expected deployment to stop, got error: %v", err)
+	}
+	if deployment.Status.State != "CANCELLED" {
+		t.Errorf("Expected state 'CANCELLED', got '%s'", deployment.Status.State)
+	}
+}
+
+func TestWaitForSessionClusterFailure(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, SessionCluster{Status: SessionClusterStatus{
+			State:   "FAILED",
+			Failure: &Failure{Reason: "ImagePullBackOff", Message: "image not found"},
+		}})
+	})
+
+	_, err := client.WaitForSessionClusterState("default", "sc", "RUNNING", fastWait)
+	if err == nil || !strings.Contains(err.Error(), "ImagePullBackOff: image not found") {
+		t.Errorf("Expected failure reason in error, got %v", err)
+	}
+}
+
+func TestWaitForSessionClusterTimeoutReportsFailure(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, SessionCluster{Status: SessionClusterStatus{
+			State:   "FAILED",
+			Failure: &Failure{Reason: "ImagePullBackOff", Message: "image not found"},
+		}})
+	})
+
+	// A condition that waits out a FAILED state still reports its failure
+	opts := WaitOptions{Timeout: 50 * time.Millisecond, Interval: 10 * time.Millisecond}
+	_, err := client.WaitForSessionCluster("default", "sc", opts, func(*SessionCluster) (bool, error) {
+		return false, nil
+	})
+	if !errors.Is(err, ErrWaitTimeout) || !strings.Contains(err.Error(), "last state: FAILED: ImagePullBackOff: image not found") {
+		t.Errorf("Expected timeout with the failure reason, got %v", err)
+	}
+}
+
+func TestWaitForSavepoint(t *testing.T) {
+	states := []string{"STARTED", "COMPLETED"}
+	calls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		state := states[min(calls, len(states)-1)]
+		calls++
+		writeJSON(w, Savepoint{Status: SavepointStatus{State: state}})
+	})
+
+	savepoint, err := client.WaitForSavepoint("default", "sp-1", fastWait)
+	if err != nil {
+		t.Fatalf("Expected savepoint to complete, got error: %v", err)
+	}
+	if savepoint.Status.State != "COMPLETED" {
+		t.Errorf("Expected state 'COMPLETED', got '%s'", savepoint.Status.State)
+	}
+}
+
+func TestListDeploymentSavepoints(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("deploymentId"); got != "dep-1" {
+			t.Errorf("Expected deploymentId query 'dep-1', got '%s'", got)
+		}
+		writeJSON(w, SavepointList{Items: []Savepoint{
+			{Metadata: SavepointMetadata{ID: "sp-1"}, Spec: SavepointSpec{DeploymentID: "dep-1"}},
+			{Metadata: SavepointMetadata{ID: "sp-2"}, Spec: SavepointSpec{DeploymentID: "dep-2"}},
+		}})
+	})
+
+	savepoints, err := client.ListDeploymentSavepoints("default", "dep-1")
+	if err != nil {
+		t.Fatalf("Failed to list savepoints: %v", err)
+	}
+	if len(savepoints) != 1 || savepoints[0].Metadata.ID != "sp-1" {
+		t.Errorf("Expected only savepoint 'sp-1', got %+v", savepoints)
+	}
+}
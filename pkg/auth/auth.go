@@ -0,0 +1,70 @@
+// Package auth resolves the bearer token used to authenticate API requests.
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"mcolomerc/vvp2cli/pkg/config"
+)
+
+// TokenSource supplies the bearer token for API requests. An empty token
+// means requests are sent without an Authorization header.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticTokenSource always returns the same token
+type StaticTokenSource string
+
+// Token returns the static token
+func (s StaticTokenSource) Token(context.Context) (string, error) {
+	return string(s), nil
+}
+
+// FileTokenSource reads the token from a file on every call, so rotated
+// tokens are picked up without restarting long-running callers
+type FileTokenSource struct {
+	Path string
+}
+
+// Token returns the trimmed contents of the token file
+func (s *FileTokenSource) Token(context.Context) (string, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", s.Path)
+	}
+	return token, nil
+}
+
+// NewTokenSource returns the token source configured in cfg. A static token
+// takes precedence, followed by tokenFile, tokenExec, tokenKeyring and oidc.
+// It returns nil when no credentials are configured. httpClient is used for
+// requests to the OIDC provider.
+func NewTokenSource(cfg *config.Config, httpClient *http.Client) (TokenSource, error) {
+	api := &cfg.API
+	switch {
+	case api.Token != "":
+		return StaticTokenSource(api.Token), nil
+	case api.TokenFile != "":
+		return &FileTokenSource{Path: config.ExpandHome(api.TokenFile)}, nil
+	case api.TokenExec != nil && api.TokenExec.Command != "":
+		return NewExecTokenSource(api.TokenExec, cfg.ActiveContext), nil
+	case api.TokenKeyring != nil && (api.TokenKeyring.Service != "" || api.TokenKeyring.Account != ""):
+		return NewKeyringTokenSource(api.TokenKeyring)
+	case api.OIDC != nil && (api.OIDC.Issuer != "" || api.OIDC.TokenEndpoint != ""):
+		store, err := DefaultTokenStore()
+		if err != nil {
+			return nil, err
+		}
+		return NewOIDCTokenSource(NewOIDCClient(api.OIDC, httpClient), store, cfg.ActiveContext), nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"mcolomerc/vvp2cli/pkg/config"
+)
+
+func TestNewTokenSourcePrecedence(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.APIConfig
+		want string
+	}{
+		{"none", config.APIConfig{}, "<nil>"},
+		{"static", config.APIConfig{Token: "abc", TokenFile: "/tmp/token"}, "auth.StaticTokenSource"},
+		{"file", config.APIConfig{TokenFile: "/tmp/token"}, "*auth.FileTokenSource"},
+		{"exec", config.APIConfig{TokenExec: &config.ExecConfig{Command: "helper"}}, "*auth.ExecTokenSource"},
+	}
+
+	for _, tt := range tests {
+		source, err := NewTokenSource(&config.Config{API: tt.cfg}, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		got := "<nil>"
+		if source != nil {
+			got = fmt.Sprintf("%T", source)
+		}
+		if got != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestFileTokenSourceRereads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	source := &FileTokenSource{Path: path}
+
+	os.WriteFile(path, []byte("first\n"), 0600)
+	if token, err := source.Token(context.Background()); err != nil || token != "first" {
+		t.Errorf("Expected token 'first', got '%s' (%v)", token, err)
+	}
+
+	os.WriteFile(path, []byte("second"), 0600)
+	if token, err := source.Token(context.Background()); err != nil || token != "second" {
+		t.Errorf("Expected rotated token 'second', got '%s' (%v)", token, err)
+	}
+
+	os.WriteFile(path, []byte("  \n"), 0600)
+	if _, err := source.Token(context.Background()); err == nil {
+		t.Error("Expected error for empty token file")
+	}
+}
+
+// writeHelper creates a credential helper script that counts its invocations
+func writeHelper(t *testing.T, output string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	counter := filepath.Join(dir, "calls")
+	script := filepath.Join(dir, "helper.sh")
+	content := "#!/bin/sh\necho x >> " + counter + "\ncat <<'EOF'\n" + output + "\nEOF\n"
+	if err := os.WriteFile(script, []byte(content), 0700); err != nil {
+		t.Fatalf("Failed to write helper: %v", err)
+	}
+	return script, counter
+}
+
+func helperCalls(counter string) int {
+	data, _ := os.ReadFile(counter)
+	return strings.Count(string(data), "x")
+}
+
+func TestExecTokenSourceCachesUntilExpiry(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	expiry := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	script, counter := writeHelper(t, `{"token": "exec-token", "expiry": "`+expiry+`"}`)
+	cacheDir := t.TempDir()
+
+	source := &ExecTokenSource{Command: script, CacheDir: cacheDir}
+	for i := 0; i < 3; i++ {
+		token, err := source.Token(context.Background())
+		if err != nil || token != "exec-token" {
+			t.Fatalf("Expected token 'exec-token', got '%s' (%v)", token, err)
+		}
+	}
+	if calls := helperCalls(counter); calls != 1 {
+		t.Errorf("Expected helper to run once, ran %d times", calls)
+	}
+
+	// A new source (next invocation) uses the file cache
+	next := &ExecTokenSource{Command: script, CacheDir: cacheDir}
+	if token, err := next.Token(context.Background()); err != nil || token != "exec-token" {
+		t.Fatalf("Expected cached token, got '%s' (%v)", token, err)
+	}
+	if calls := helperCalls(counter); calls != 1 {
+		t.Errorf("Expected cached token to be reused, helper ran %d times", calls)
+	}
+}
+
+func TestExecTokenSourcePlainOutput(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	script, counter := writeHelper(t, "plain-token")
+
+	cacheDir := t.TempDir()
+	source := &ExecTokenSource{Command: script, CacheDir: cacheDir}
+	for i := 0; i < 3; i++ {
+		if token, err := source.Token(context.Background()); err != nil || token != "plain-token" {
+			t.Fatalf("Expected token 'plain-token', got '%s' (%v)", token, err)
+		}
+	}
+	if calls := helperCalls(counter); calls != 1 {
+		t.Errorf("Expected token without expiry to be kept in memory, helper ran %d times", calls)
+	}
+
+	// It is not written to the file cache, so the next invocation runs the helper
+	next := &ExecTokenSource{Command: script, CacheDir: cacheDir}
+	if _, err := next.Token(context.Background()); err != nil {
+		t.Fatalf("Failed to get token: %v", err)
+	}
+	if calls := helperCalls(counter); calls != 2 {
+		t.Errorf("Expected token without expiry not to be cached on disk, helper ran %d times", calls)
+	}
+}
+
+func TestExecTokenSourceCachePath(t *testing.T) {
+	path := func(context string, env map[string]string) string {
+		source := &ExecTokenSource{Command: "helper", Args: []string{"get-token"}, Env: env, Context: context, CacheDir: "/cache"}
+		return source.cachePath()
+	}
+	base := path("dev", map[string]string{"ACCOUNT": "dev", "REGION": "eu"})
+
+	if base != path("dev", map[string]string{"REGION": "eu", "ACCOUNT": "dev"}) {
+		t.Error("Expected the cache path not to depend on env order")
+	}
+	if base == path("dev", map[string]string{"ACCOUNT": "prod", "REGION": "eu"}) {
+		t.Error("Expected a different cache path for a different env")
+	}
+	if base == path("prod", map[string]string{"ACCOUNT": "dev", "REGION": "eu"}) {
+		t.Error("Expected a different cache path for a different context")
+	}
+}
+
+func TestParseHelperOutput(t *testing.T) {
+	invalid := []string{"", "   ", `{"expiry": "2030-01-01T00:00:00Z"}`, `{"token": `}
+	for _, output := range invalid {
+		if _, err := parseHelperOutput([]byte(output)); err == nil {
+			t.Errorf("Expected error for helper output %q", output)
+		}
+	}
+}
+
+func TestKeyringTokenSource(t *testing.T) {
+	source := &KeyringTokenSource{
+		Service: "vvp2",
+		Account: "prod",
+		lookup: func(ctx context.Context, service, account string) *exec.Cmd {
+			return exec.CommandContext(ctx, "echo", service+"-"+account+"-token")
+		},
+	}
+
+	token, err := source.Token(context.Background())
+	if err != nil || token != "vvp2-prod-token" {
+		t.Errorf("Expected token 'vvp2-prod-token', got '%s' (%v)", token, err)
+	}
+}
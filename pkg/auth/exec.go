@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"mcolomerc/vvp2cli/pkg/config"
+)
+
+// expirySkew renews cached tokens slightly before they expire
+const expirySkew = 30 * time.Second
+
+// ExecTokenSource runs an external credential helper to obtain a token.
+//
+// The helper prints either the bare token, or a JSON object such as
+// {"token": "...", "expiry": "2024-05-01T12:00:00Z"}. Tokens with an expiry
+// are cached, in memory and in CacheDir, until shortly before they expire.
+// Tokens without one are kept in memory for the lifetime of the process.
+type ExecTokenSource struct {
+	Command string
+	Args    []string
+	Env     map[string]string
+	// Context is the config context the helper runs for; it keeps the file
+	// caches of contexts that share a helper apart
+	Context string
+	// CacheDir persists tokens between invocations; empty disables the file cache
+	CacheDir string
+
+	mu    sync.Mutex
+	token cachedToken
+}
+
+// cachedToken is a token with its optional expiry
+type cachedToken struct {
+	Token  string    `json:"token"`
+	Expiry time.Time `json:"expiry,omitempty"`
+}
+
+// valid reports whether the token can still be used; tokens without an
+// expiry do not expire
+func (t cachedToken) valid() bool {
+	return t.Token != "" && (t.Expiry.IsZero() || time.Now().Add(expirySkew).Before(t.Expiry))
+}
+
+// NewExecTokenSource returns a token source for the helper configured in
+// context, caching tokens under ~/.vvp2/cache
+func NewExecTokenSource(cfg *config.ExecConfig, context string) *ExecTokenSource {
+	source := &ExecTokenSource{
+		Command: config.ExpandHome(cfg.Command),
+		Args:    cfg.Args,
+		Env:     cfg.Env,
+		Context: context,
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		source.CacheDir = filepath.Join(home, ".vvp2", "cache")
+	}
+	return source
+}
+
+// Token returns a cached token or runs the helper for a new one
+func (s *ExecTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.valid() {
+		return s.token.Token, nil
+	}
+	if cached, ok := s.readCache(); ok {
+		s.token = cached
+		return cached.Token, nil
+	}
+
+	token, err := s.run(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.token = token
+	if !token.Expiry.IsZero() {
+		s.writeCache(token)
+	}
+	return token.Token, nil
+}
+
+// run executes the helper and parses its output
+func (s *ExecTokenSource) run(ctx context.Context) (cachedToken, error) {
+	cmd := exec.CommandContext(ctx, s.Command, s.Args...)
+	cmd.Env = os.Environ()
+	for key, value := range s.Env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	// Helpers may prompt or log on stderr
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return cachedToken{}, fmt.Errorf("credential helper %s failed: %w", s.Command, err)
+	}
+	return parseHelperOutput(stdout.Bytes())
+}
+
+// parseHelperOutput accepts a JSON token object or a bare token
+func parseHelperOutput(output []byte) (cachedToken, error) {
+	output = bytes.TrimSpace(output)
+	if len(output) == 0 {
+		return cachedToken{}, fmt.Errorf("credential helper returned no token")
+	}
+
+	if output[0] == '{' {
+		var token cachedToken
+		if err := json.Unmarshal(output, &token); err != nil {
+			return cachedToken{}, fmt.Errorf("invalid credential helper output: %w", err)
+		}
+		if token.Token == "" {
+			return cachedToken{}, fmt.Errorf("credential helper output has no token field")
+		}
+		return token, nil
+	}
+	return cachedToken{Token: string(output)}, nil
+}
+
+// cachePath returns the cache file for this helper invocation. The key covers
+// everything that can change the token the helper returns: the context, the
+// command, its arguments and its environment.
+func (s *ExecTokenSource) cachePath() string {
+	if s.CacheDir == "" {
+		return ""
+	}
+	// encoding/json writes map keys sorted, so the key is stable
+	key, _ := json.Marshal([]interface{}{s.Context, s.Command, s.Args, s.Env})
+	sum := sha256.Sum256(key)
+	return filepath.Join(s.CacheDir, "exec-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// readCache returns a still valid token from the cache file
+func (s *ExecTokenSource) readCache() (cachedToken, bool) {
+	path := s.cachePath()
+	if path == "" {
+		return cachedToken{}, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cachedToken{}, false
+	}
+	var token cachedToken
+	if json.Unmarshal(data, &token) != nil || token.Expiry.IsZero() || !token.valid() {
+		return cachedToken{}, false
+	}
+	return token, true
+}
+
+// writeCache stores the token; failures only cost a helper run next time
+func (s *ExecTokenSource) writeCache(token cachedToken) {
+	path := s.cachePath()
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(token)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	os.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"mcolomerc/vvp2cli/pkg/config"
+)
+
+// DefaultKeyringService is the service name used when none is configured
+const DefaultKeyringService = "vvp2"
+
+// KeyringTokenSource reads the token from the OS secret service: the login
+// keychain on macOS (security) or the Secret Service API on Linux
+// (secret-tool from libsecret).
+type KeyringTokenSource struct {
+	Service string
+	Account string
+
+	// lookup returns the command that prints the secret
+	lookup func(ctx context.Context, service, account string) *exec.Cmd
+}
+
+// NewKeyringTokenSource returns a keyring token source for the current OS
+func NewKeyringTokenSource(cfg *config.KeyringConfig) (*KeyringTokenSource, error) {
+	service := cfg.Service
+	if service == "" {
+		service = DefaultKeyringService
+	}
+
+	source := &KeyringTokenSource{Service: service, Account: cfg.Account}
+	switch runtime.GOOS {
+	case "darwin":
+		source.lookup = func(ctx context.Context, service, account string) *exec.Cmd {
+			args := []string{"find-generic-password", "-s", service, "-w"}
+			if account != "" {
+				args = append(args, "-a", account)
+			}
+			return exec.CommandContext(ctx, "security", args...)
+		}
+	case "linux", "freebsd", "openbsd", "netbsd":
+		source.lookup = func(ctx context.Context, service, account string) *exec.Cmd {
+			args := []string{"lookup", "service", service}
+			if account != "" {
+				args = append(args, "account", account)
+			}
+			return exec.CommandContext(ctx, "secret-tool", args...)
+		}
+	default:
+		return nil, fmt.Errorf("OS keyring is not supported on %s; use tokenFile or tokenExec instead", runtime.GOOS)
+	}
+	return source, nil
+}
+
+// Token looks up the token in the keyring
+func (s *KeyringTokenSource) Token(ctx context.Context) (string, error) {
+	cmd := s.lookup(ctx, s.Service, s.Account)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", fmt.Errorf("keyring tool %s not found: %w", cmd.Path, err)
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("no token found in keyring for service %q: %s", s.Service, msg)
+	}
+
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("no token found in keyring for service %q", s.Service)
+	}
+	return token, nil
+}
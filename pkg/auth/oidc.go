@@ -0,0 +1,389 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"mcolomerc/vvp2cli/pkg/config"
+)
+
+// Grant types used with the token endpoint
+const (
+	grantDeviceCode        = "urn:ietf:params:oauth:grant-type:device_code"
+	grantClientCredentials = "client_credentials"
+	grantRefreshToken      = "refresh_token"
+)
+
+// ErrNotLoggedIn is returned when no OIDC token is stored and none can be obtained non-interactively
+var ErrNotLoggedIn = errors.New("not logged in; run 'vvp2 login'")
+
+// Token is an OAuth2 token response
+type Token struct {
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken,omitempty"`
+	TokenType    string    `json:"tokenType,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+// Valid reports whether the access token can be used without refreshing
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(expirySkew).Before(t.Expiry)
+}
+
+// DeviceAuthorization is the response of the device authorization endpoint
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// OIDCClient talks to an OpenID Connect provider's OAuth2 endpoints
+type OIDCClient struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	Audience     string
+	// TokenEndpoint and DeviceEndpoint are discovered from the issuer when empty
+	TokenEndpoint  string
+	DeviceEndpoint string
+
+	HTTPClient *http.Client
+}
+
+// NewOIDCClient returns a client for the configured provider that sends its
+// requests with httpClient, or with a client with a 30s timeout if it is nil
+func NewOIDCClient(cfg *config.OIDCConfig, httpClient *http.Client) *OIDCClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &OIDCClient{
+		Issuer:         strings.TrimSuffix(cfg.Issuer, "/"),
+		ClientID:       cfg.ClientID,
+		ClientSecret:   cfg.ClientSecret,
+		Scopes:         cfg.Scopes,
+		Audience:       cfg.Audience,
+		TokenEndpoint:  cfg.TokenEndpoint,
+		DeviceEndpoint: cfg.DeviceEndpoint,
+		HTTPClient:     httpClient,
+	}
+}
+
+// Discover fills in missing endpoints from the issuer's discovery document
+func (c *OIDCClient) Discover(ctx context.Context) error {
+	if c.Issuer == "" {
+		return fmt.Errorf("OIDC issuer is not configured (set api.oidc.issuer)")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.Issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch OIDC discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch OIDC discovery document: status %d", resp.StatusCode)
+	}
+
+	var metadata struct {
+		TokenEndpoint               string `json:"token_endpoint"`
+		DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return fmt.Errorf("invalid OIDC discovery document: %w", err)
+	}
+	if c.TokenEndpoint == "" {
+		c.TokenEndpoint = metadata.TokenEndpoint
+	}
+	if c.DeviceEndpoint == "" {
+		c.DeviceEndpoint = metadata.DeviceAuthorizationEndpoint
+	}
+	if c.TokenEndpoint == "" {
+		return fmt.Errorf("OIDC provider %s does not advertise a token endpoint", c.Issuer)
+	}
+	return nil
+}
+
+// ensureTokenEndpoint discovers the token endpoint unless it is already known
+func (c *OIDCClient) ensureTokenEndpoint(ctx context.Context) error {
+	if c.TokenEndpoint != "" {
+		return nil
+	}
+	return c.Discover(ctx)
+}
+
+// StartDeviceAuthorization begins the device authorization flow
+func (c *OIDCClient) StartDeviceAuthorization(ctx context.Context) (*DeviceAuthorization, error) {
+	if c.DeviceEndpoint == "" {
+		if err := c.Discover(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if c.DeviceEndpoint == "" {
+		return nil, fmt.Errorf("OIDC provider does not support the device authorization flow")
+	}
+
+	form := c.baseForm()
+	var auth DeviceAuthorization
+	if err := c.post(ctx, c.DeviceEndpoint, form, &auth); err != nil {
+		return nil, fmt.Errorf("device authorization failed: %w", err)
+	}
+	if auth.Interval <= 0 {
+		auth.Interval = 5
+	}
+	return &auth, nil
+}
+
+// PollDeviceToken polls the token endpoint until the user approves or denies
+// the device authorization, or it expires
+func (c *OIDCClient) PollDeviceToken(ctx context.Context, auth *DeviceAuthorization) (*Token, error) {
+	interval := time.Duration(auth.Interval) * time.Second
+	var deadline time.Time
+	if auth.ExpiresIn > 0 {
+		deadline = time.Now().Add(time.Duration(auth.ExpiresIn) * time.Second)
+	}
+
+	form := c.baseForm()
+	form.Set("grant_type", grantDeviceCode)
+	form.Set("device_code", auth.DeviceCode)
+
+	for {
+		token, err := c.requestToken(ctx, form)
+		var oauthErr *OAuthError
+		switch {
+		case err == nil:
+			return token, nil
+		case errors.As(err, &oauthErr) && oauthErr.Code == "authorization_pending":
+		case errors.As(err, &oauthErr) && oauthErr.Code == "slow_down":
+			interval += 5 * time.Second
+		default:
+			return nil, err
+		}
+
+		if !deadline.IsZero() && time.Now().Add(interval).After(deadline) {
+			return nil, fmt.Errorf("device authorization expired; run 'vvp2 login' again")
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// ClientCredentials obtains a token with the client credentials grant
+func (c *OIDCClient) ClientCredentials(ctx context.Context) (*Token, error) {
+	if c.ClientSecret == "" {
+		return nil, fmt.Errorf("client credentials flow requires a client secret (set api.oidc.clientSecret)")
+	}
+	if err := c.ensureTokenEndpoint(ctx); err != nil {
+		return nil, err
+	}
+
+	form := c.baseForm()
+	form.Set("grant_type", grantClientCredentials)
+	return c.requestToken(ctx, form)
+}
+
+// Refresh exchanges a refresh token for a new access token
+func (c *OIDCClient) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	if err := c.ensureTokenEndpoint(ctx); err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", grantRefreshToken)
+	form.Set("refresh_token", refreshToken)
+	form.Set("client_id", c.ClientID)
+	if c.ClientSecret != "" {
+		form.Set("client_secret", c.ClientSecret)
+	}
+
+	token, err := c.requestToken(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+	// Providers may omit the refresh token when it is not rotated
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	return token, nil
+}
+
+// baseForm returns the client identification and requested scopes
+func (c *OIDCClient) baseForm() url.Values {
+	form := url.Values{}
+	form.Set("client_id", c.ClientID)
+	if c.ClientSecret != "" {
+		form.Set("client_secret", c.ClientSecret)
+	}
+	if len(c.Scopes) > 0 {
+		form.Set("scope", strings.Join(c.Scopes, " "))
+	}
+	if c.Audience != "" {
+		form.Set("audience", c.Audience)
+	}
+	return form
+}
+
+// requestToken posts a grant to the token endpoint
+func (c *OIDCClient) requestToken(ctx context.Context, form url.Values) (*Token, error) {
+	var resp struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		TokenType    string `json:"token_type"`
+		ExpiresIn    int64  `json:"expires_in"`
+	}
+	if err := c.post(ctx, c.TokenEndpoint, form, &resp); err != nil {
+		return nil, err
+	}
+	if resp.AccessToken == "" {
+		return nil, fmt.Errorf("token endpoint returned no access token")
+	}
+
+	token := &Token{
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+		TokenType:    resp.TokenType,
+	}
+	if resp.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// OAuthError is an error response from an OAuth2 endpoint
+type OAuthError struct {
+	StatusCode  int
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e *OAuthError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("%s: %s", e.Code, e.Description)
+	}
+	if e.Code != "" {
+		return e.Code
+	}
+	return fmt.Sprintf("status %d", e.StatusCode)
+}
+
+// post sends a form and decodes the JSON response into result
+func (c *OIDCClient) post(ctx context.Context, endpoint string, form url.Values, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		oauthErr := &OAuthError{StatusCode: resp.StatusCode}
+		json.Unmarshal(body, oauthErr)
+		return oauthErr
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("invalid response from %s: %w", endpoint, err)
+	}
+	return nil
+}
+
+// OIDCTokenSource returns the access token stored by vvp2 login for a
+// context, refreshing it when it is about to expire. Clients with a secret
+// fall back to the client credentials grant, so CI does not need a login step.
+type OIDCTokenSource struct {
+	Client *OIDCClient
+	Store  *TokenStore
+	// Key is the context the credential is stored under
+	Key string
+
+	mu   sync.Mutex
+	cred *StoredCredential
+}
+
+// NewOIDCTokenSource returns a token source for the credential stored under key
+func NewOIDCTokenSource(client *OIDCClient, store *TokenStore, key string) *OIDCTokenSource {
+	return &OIDCTokenSource{Client: client, Store: store, Key: key}
+}
+
+// Token returns a valid access token, refreshing and persisting it if needed
+func (s *OIDCTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cred == nil {
+		cred, err := s.Store.Load(s.Key)
+		if err != nil {
+			return "", err
+		}
+		s.cred = cred
+	}
+	if s.cred != nil && s.cred.Valid() {
+		return s.cred.AccessToken, nil
+	}
+
+	var refreshErr error
+	if s.cred != nil && s.cred.RefreshToken != "" {
+		if s.Client.TokenEndpoint == "" {
+			s.Client.TokenEndpoint = s.cred.TokenEndpoint
+		}
+		token, err := s.Client.Refresh(ctx, s.cred.RefreshToken)
+		if err == nil {
+			return s.save(token)
+		}
+		refreshErr = err
+	}
+
+	if s.Client.ClientSecret != "" {
+		token, err := s.Client.ClientCredentials(ctx)
+		if err != nil {
+			return "", fmt.Errorf("client credentials login failed: %w", err)
+		}
+		return s.save(token)
+	}
+
+	if refreshErr != nil {
+		return "", fmt.Errorf("failed to refresh access token (%v): %w", refreshErr, ErrNotLoggedIn)
+	}
+	if s.cred != nil {
+		return "", fmt.Errorf("access token expired: %w", ErrNotLoggedIn)
+	}
+	return "", ErrNotLoggedIn
+}
+
+// save persists a new token and returns its access token
+func (s *OIDCTokenSource) save(token *Token) (string, error) {
+	s.cred = &StoredCredential{Token: *token, TokenEndpoint: s.Client.TokenEndpoint}
+	if err := s.Store.Save(s.Key, s.cred); err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeProvider is a stand-in OIDC provider with device and token endpoints
+type fakeProvider struct {
+	server       *httptest.Server
+	pending      int
+	grants       []string
+	refreshToken string
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	t.Helper()
+	p := &fakeProvider{refreshToken: "refresh-1"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"token_endpoint":                p.server.URL + "/token",
+			"device_authorization_endpoint": p.server.URL + "/device",
+		})
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"device_code":      "device-123",
+			"user_code":        "ABCD-EFGH",
+			"verification_uri": p.server.URL + "/activate",
+			"expires_in":       60,
+			"interval":         1,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		grant := r.PostForm.Get("grant_type")
+		p.grants = append(p.grants, grant)
+
+		switch grant {
+		case grantDeviceCode:
+			if p.pending > 0 {
+				p.pending--
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": "authorization_pending"})
+				return
+			}
+		case grantClientCredentials:
+			if r.PostForm.Get("client_secret") != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client", "error_description": "bad secret"})
+				return
+			}
+		case grantRefreshToken:
+			if r.PostForm.Get("refresh_token") != p.refreshToken {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+				return
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "access-" + grant,
+			"refresh_token": p.refreshToken,
+			"token_type":    "Bearer",
+			"expires_in":    3600,
+		})
+	})
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func (p *fakeProvider) client(secret string) *OIDCClient {
+	return &OIDCClient{Issuer: p.server.URL, ClientID: "vvp2", ClientSecret: secret, HTTPClient: p.server.Client()}
+}
+
+func TestDeviceFlow(t *testing.T) {
+	provider := newFakeProvider(t)
+	provider.pending = 1
+	client := provider.client("")
+
+	device, err := client.StartDeviceAuthorization(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to start device authorization: %v", err)
+	}
+	if device.UserCode != "ABCD-EFGH" {
+		t.Errorf("Expected user code 'ABCD-EFGH', got '%s'", device.UserCode)
+	}
+
+	device.Interval = 0 // poll without waiting a full second per attempt
+	token, err := client.PollDeviceToken(context.Background(), device)
+	if err != nil {
+		t.Fatalf("Failed to poll device token: %v", err)
+	}
+	if token.AccessToken != "access-"+grantDeviceCode || token.RefreshToken != "refresh-1" {
+		t.Errorf("Unexpected token: %+v", token)
+	}
+	if token.Expiry.Before(time.Now().Add(time.Hour - time.Minute)) {
+		t.Errorf("Expected expiry about an hour from now, got %v", token.Expiry)
+	}
+}
+
+func TestClientCredentials(t *testing.T) {
+	provider := newFakeProvider(t)
+
+	token, err := provider.client("secret").ClientCredentials(context.Background())
+	if err != nil || token.AccessToken != "access-client_credentials" {
+		t.Fatalf("Expected client credentials token, got %+v (%v)", token, err)
+	}
+
+	_, err = provider.client("wrong").ClientCredentials(context.Background())
+	var oauthErr *OAuthError
+	if !errors.As(err, &oauthErr) || oauthErr.Code != "invalid_client" {
+		t.Errorf("Expected invalid_client error, got %v", err)
+	}
+}
+
+func TestOIDCTokenSourceRefreshes(t *testing.T) {
+	provider := newFakeProvider(t)
+	store := &TokenStore{Path: filepath.Join(t.TempDir(), "credentials.json")}
+	store.Save("prod", &StoredCredential{
+		Token:         Token{AccessToken: "old", RefreshToken: "refresh-1", Expiry: time.Now().Add(-time.Minute)},
+		TokenEndpoint: provider.server.URL + "/token",
+	})
+
+	client := provider.client("")
+	client.Issuer = "" // the stored token endpoint must be enough to refresh
+	source := NewOIDCTokenSource(client, store, "prod")
+
+	token, err := source.Token(context.Background())
+	if err != nil || token != "access-refresh_token" {
+		t.Fatalf("Expected refreshed token, got '%s' (%v)", token, err)
+	}
+	if token, _ := source.Token(context.Background()); token != "access-refresh_token" {
+		t.Errorf("Expected cached token, got '%s'", token)
+	}
+	if len(provider.grants) != 1 {
+		t.Errorf("Expected a single refresh, got grants %v", provider.grants)
+	}
+
+	cred, err := store.Load("prod")
+	if err != nil || cred == nil || cred.AccessToken != "access-refresh_token" {
+		t.Errorf("Expected refreshed token to be stored, got %+v (%v)", cred, err)
+	}
+}
+
+func TestOIDCTokenSourceNotLoggedIn(t *testing.T) {
+	provider := newFakeProvider(t)
+	store := &TokenStore{Path: filepath.Join(t.TempDir(), "credentials.json")}
+
+	_, err := NewOIDCTokenSource(provider.client(""), store, "").Token(context.Background())
+	if !errors.Is(err, ErrNotLoggedIn) {
+		t.Errorf("Expected ErrNotLoggedIn, got %v", err)
+	}
+
+	// With a client secret, the token source logs in by itself
+	token, err := NewOIDCTokenSource(provider.client("secret"), store, "").Token(context.Background())
+	if err != nil || token != "access-client_credentials" {
+		t.Errorf("Expected client credentials token, got '%s' (%v)", token, err)
+	}
+	if cred, _ := store.Load(DefaultStoreKey); cred == nil {
+		t.Error("Expected token to be stored under the default key")
+	}
+}
@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// DefaultStoreKey is used for credentials when no context is active
+const DefaultStoreKey = "default"
+
+// StoredCredential is an OIDC login persisted for one context. The token
+// endpoint is kept so refreshing does not need provider discovery.
+type StoredCredential struct {
+	Token
+	TokenEndpoint string `json:"tokenEndpoint,omitempty"`
+}
+
+// TokenStore persists OIDC credentials per context in a JSON file that only
+// the owner can read
+type TokenStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// DefaultTokenStore returns the store at ~/.vvp2/credentials.json
+func DefaultTokenStore() (*TokenStore, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return &TokenStore{Path: filepath.Join(home, ".vvp2", "credentials.json")}, nil
+}
+
+// Load returns the credential stored for key, or nil if there is none
+func (s *TokenStore) Load(key string) (*StoredCredential, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return all[storeKey(key)], nil
+}
+
+// Save stores the credential for key
+func (s *TokenStore) Save(key string, cred *StoredCredential) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+	all[storeKey(key)] = cred
+	return s.write(all)
+}
+
+// Delete removes the credential for key and reports whether it existed
+func (s *TokenStore) Delete(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return false, err
+	}
+	if _, ok := all[storeKey(key)]; !ok {
+		return false, nil
+	}
+	delete(all, storeKey(key))
+	return true, s.write(all)
+}
+
+func (s *TokenStore) read() (map[string]*StoredCredential, error) {
+	all := map[string]*StoredCredential{}
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials: %w", err)
+	}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("invalid credentials file %s: %w", s.Path, err)
+	}
+	return all, nil
+}
+
+func (s *TokenStore) write(all map[string]*StoredCredential) error {
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return fmt.Errorf("failed to create credentials directory: %w", err)
+	}
+	// Write to a temporary file first so a crash never truncates the store
+	tmp := s.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+	if err := os.Rename(tmp, s.Path); err != nil {
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+	return nil
+}
+
+// storeKey maps an empty context name to DefaultStoreKey
+func storeKey(key string) string {
+	if key == "" {
+		return DefaultStoreKey
+	}
+	return key
+}
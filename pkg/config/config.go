@@ -2,6 +2,9 @@ package config
 
 import (
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -11,6 +14,15 @@ type Config struct {
 	API     APIConfig     `mapstructure:"api"`
 	Default DefaultConfig `mapstructure:"default"`
 	Output  OutputConfig  `mapstructure:"output"`
+
+	// Contexts are named API/default/output settings, selected with
+	// current-context or the --context flag
+	CurrentContext string    `mapstructure:"current-context"`
+	Contexts       []Context `mapstructure:"contexts"`
+
+	// ActiveContext is the name of the context merged into this configuration,
+	// or empty when only top-level settings are used
+	ActiveContext string `mapstructure:"-"`
 }
 
 // APIConfig holds API-related configuration
@@ -18,8 +30,64 @@ type APIConfig struct {
 	URL      string `mapstructure:"url"`
 	Token    string `mapstructure:"token"`
 	Insecure bool   `mapstructure:"insecure"`
+
+	// CAFile is a PEM bundle trusted in addition to the system roots
+	CAFile string `mapstructure:"caFile"`
+	// ClientCert and ClientKey are PEM files used for mutual TLS
+	ClientCert string `mapstructure:"clientCert"`
+	ClientKey  string `mapstructure:"clientKey"`
+
+	// Proxy is the HTTP(S) proxy URL for API requests, and NoProxy the
+	// comma-separated hosts that bypass it. When unset, HTTPS_PROXY,
+	// HTTP_PROXY and NO_PROXY from the environment are used.
+	Proxy   string `mapstructure:"proxy"`
+	NoProxy string `mapstructure:"noProxy"`
+
+	// Alternative token sources, used when Token is empty
+	TokenFile    string         `mapstructure:"tokenFile"`
+	TokenExec    *ExecConfig    `mapstructure:"tokenExec"`
+	TokenKeyring *KeyringConfig `mapstructure:"tokenKeyring"`
+	OIDC         *OIDCConfig    `mapstructure:"oidc"`
+
+	// Retries is how many times failed idempotent requests are retried (0 disables retries)
+	Retries          int           `mapstructure:"retries"`
+	RetryWaitTime    time.Duration `mapstructure:"retryWaitTime"`
+	RetryMaxWaitTime time.Duration `mapstructure:"retryMaxWaitTime"`
+	// RetryAllMethods also retries POST and PATCH requests, which may not be safe to repeat
+	RetryAllMethods bool `mapstructure:"retryAllMethods"`
+}
+
+// ExecConfig configures an external credential helper that prints a token
+type ExecConfig struct {
+	Command string            `mapstructure:"command"`
+	Args    []string          `mapstructure:"args"`
+	Env     map[string]string `mapstructure:"env"`
 }
 
+// KeyringConfig identifies a token stored in the OS secret service
+type KeyringConfig struct {
+	Service string `mapstructure:"service"`
+	Account string `mapstructure:"account"`
+}
+
+// OIDCConfig configures login against an OpenID Connect provider with
+// vvp2 login. Endpoints are discovered from the issuer unless set.
+type OIDCConfig struct {
+	Issuer         string   `mapstructure:"issuer"`
+	ClientID       string   `mapstructure:"clientId"`
+	ClientSecret   string   `mapstructure:"clientSecret"`
+	Scopes         []string `mapstructure:"scopes"`
+	Audience       string   `mapstructure:"audience"`
+	TokenEndpoint  string   `mapstructure:"tokenEndpoint"`
+	DeviceEndpoint string   `mapstructure:"deviceEndpoint"`
+}
+
+// Default retry backoff bounds, used when the configuration leaves them unset
+const (
+	DefaultRetryWaitTime    = 500 * time.Millisecond
+	DefaultRetryMaxWaitTime = 10 * time.Second
+)
+
 // DefaultConfig holds default values
 type DefaultConfig struct {
 	Namespace string `mapstructure:"namespace"`
@@ -30,12 +98,25 @@ type OutputConfig struct {
 	Format string `mapstructure:"format"`
 }
 
-// LoadConfig loads configuration from viper
+// LoadConfig loads configuration from viper. If a context is selected (via the
+// "context" key, bound to the --context flag, or current-context), its settings
+// override the top-level ones; flags and environment variables still win.
 func LoadConfig() (*Config, error) {
+	return loadConfig(viper.GetViper())
+}
+
+func loadConfig(v *viper.Viper) (*Config, error) {
+	active, err := applyContext(v)
+	if err != nil {
+		return nil, err
+	}
+
 	var cfg Config
-	if err := viper.Unmarshal(&cfg); err != nil {
+	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("unable to decode config: %w", err)
 	}
+	cfg.ActiveContext = active
+	cfg.useContextCredentials()
 
 	// Validate configuration
 	if err := cfg.Validate(); err != nil {
@@ -50,6 +131,9 @@ func (c *Config) Validate() error {
 	if c.API.URL == "" {
 		return fmt.Errorf("API URL is required (set via --api-url flag, VVP_API_URL env var, or config file)")
 	}
+	if (c.API.ClientCert == "") != (c.API.ClientKey == "") {
+		return fmt.Errorf("api.clientCert and api.clientKey must be set together")
+	}
 	return nil
 }
 
@@ -73,6 +157,22 @@ func (c *Config) IsInsecure() bool {
 	return c.API.Insecure
 }
 
+// GetRetryWaitTime returns the initial backoff between retries
+func (c *Config) GetRetryWaitTime() time.Duration {
+	if c.API.RetryWaitTime <= 0 {
+		return DefaultRetryWaitTime
+	}
+	return c.API.RetryWaitTime
+}
+
+// GetRetryMaxWaitTime returns the upper bound for the backoff between retries
+func (c *Config) GetRetryMaxWaitTime() time.Duration {
+	if c.API.RetryMaxWaitTime <= 0 {
+		return DefaultRetryMaxWaitTime
+	}
+	return c.API.RetryMaxWaitTime
+}
+
 // GetOutputFormat returns the output format
 func (c *Config) GetOutputFormat() string {
 	if c.Output.Format == "" {
@@ -80,3 +180,16 @@ func (c *Config) GetOutputFormat() string {
 	}
 	return c.Output.Format
 }
+
+// ExpandHome replaces a leading ~/ in a configured path with the user's home
+// directory
+func ExpandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return home + path[1:]
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Context is a named set of settings, mirroring the top-level api, default
+// and output sections of the configuration file
+type Context struct {
+	Name    string        `mapstructure:"name"`
+	API     APIConfig     `mapstructure:"api"`
+	Default DefaultConfig `mapstructure:"default"`
+	Output  OutputConfig  `mapstructure:"output"`
+}
+
+// ErrContextNotFound is returned when the selected context does not exist
+var ErrContextNotFound = errors.New("context not found")
+
+// ContextKey is the viper key holding an explicitly selected context name
+const ContextKey = "context"
+
+// applyContext merges the selected context into v's configuration layer and
+// returns its name. The --context flag (ContextKey) takes precedence over
+// current-context; no selection leaves v untouched.
+func applyContext(v *viper.Viper) (string, error) {
+	name := v.GetString(ContextKey)
+	if name == "" {
+		name = v.GetString("current-context")
+	}
+	if name == "" {
+		return "", nil
+	}
+
+	settings, err := findContext(v.Get("contexts"), name)
+	if err != nil {
+		return "", err
+	}
+	if api, ok := toStringMap(settings["api"]); ok && replacesCredentials(api) {
+		// Blank the top-level credentials the context leaves out; merging
+		// cannot remove the tokenExec, tokenKeyring and oidc blocks, so
+		// LoadConfig replaces those after decoding
+		merged := make(map[string]interface{}, len(api)+len(scalarCredentialKeys))
+		for key, value := range api {
+			merged[key] = value
+		}
+		for _, key := range scalarCredentialKeys {
+			if !hasKey(api, key) {
+				merged[key] = ""
+			}
+		}
+		settings["api"] = merged
+	}
+	if err := v.MergeConfigMap(settings); err != nil {
+		return "", fmt.Errorf("failed to apply context %q: %w", name, err)
+	}
+	return name, nil
+}
+
+// Credential settings of the api section. A context that sets api.url or any
+// of them replaces all of them instead of merging with the top level, so that
+// selecting a context never sends the top-level credentials to its URL.
+var (
+	scalarCredentialKeys = []string{"token", "tokenFile", "clientCert", "clientKey"}
+	blockCredentialKeys  = []string{"tokenExec", "tokenKeyring", "oidc"}
+)
+
+// replacesCredentials reports whether a context's api settings replace the
+// top-level credentials
+func replacesCredentials(api map[string]interface{}) bool {
+	for _, keys := range [][]string{{"url"}, scalarCredentialKeys, blockCredentialKeys} {
+		for _, key := range keys {
+			if hasKey(api, key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// useContextCredentials replaces the credential blocks of cfg with those of
+// the active context, when that context replaces the top-level credentials
+func (c *Config) useContextCredentials() {
+	for _, ctx := range c.Contexts {
+		if ctx.Name != c.ActiveContext {
+			continue
+		}
+		api := ctx.API
+		if api.URL != "" || api.Token != "" || api.TokenFile != "" || api.ClientCert != "" || api.ClientKey != "" ||
+			api.TokenExec != nil || api.TokenKeyring != nil || api.OIDC != nil {
+			c.API.TokenExec = api.TokenExec
+			c.API.TokenKeyring = api.TokenKeyring
+			c.API.OIDC = api.OIDC
+		}
+		return
+	}
+}
+
+// hasKey reports whether m has key; decoded keys may be lowercased
+func hasKey(m map[string]interface{}, key string) bool {
+	for k := range m {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// findContext returns the settings of the named context from the raw
+// contexts list, without its name key
+func findContext(raw interface{}, name string) (map[string]interface{}, error) {
+	list, _ := raw.([]interface{})
+	for _, item := range list {
+		entry, ok := toStringMap(item)
+		if !ok || fmt.Sprint(entry["name"]) != name {
+			continue
+		}
+
+		settings := make(map[string]interface{}, len(entry))
+		for key, value := range entry {
+			if key != "name" {
+				settings[key] = value
+			}
+		}
+		return settings, nil
+	}
+	names := contextNames(list)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w: %q (no contexts are defined)", ErrContextNotFound, name)
+	}
+	return nil, fmt.Errorf("%w: %q (available: %s)", ErrContextNotFound, name, strings.Join(names, ", "))
+}
+
+// contextNames returns the names in a raw contexts list
+func contextNames(list []interface{}) []string {
+	names := []string{}
+	for _, item := range list {
+		if entry, ok := toStringMap(item); ok {
+			names = append(names, fmt.Sprint(entry["name"]))
+		}
+	}
+	return names
+}
+
+// toStringMap converts a decoded YAML mapping to map[string]interface{}
+func toStringMap(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(m))
+		for key, value := range m {
+			out[fmt.Sprint(key)] = value
+		}
+		return out, true
+	}
+	return nil, false
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+const testContextsConfig = `
+api:
+  url: http://top
+  token: top-token
+default:
+  namespace: top
+current-context: staging
+contexts:
+  - name: staging
+    api:
+      url: http://staging
+    default:
+      namespace: staging
+  - name: prod
+    api:
+      url: http://prod
+      insecure: true
+`
+
+func newTestViper(t *testing.T, content string) *viper.Viper {
+	t.Helper()
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader(content)); err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	return v
+}
+
+func TestApplyCurrentContext(t *testing.T) {
+	v := newTestViper(t, testContextsConfig)
+
+	name, err := applyContext(v)
+	if err != nil {
+		t.Fatalf("Failed to apply context: %v", err)
+	}
+	if name != "staging" {
+		t.Errorf("Expected context 'staging', got '%s'", name)
+	}
+	if got := v.GetString("api.url"); got != "http://staging" {
+		t.Errorf("Expected api.url from context, got '%s'", got)
+	}
+	if got := v.GetString("api.token"); got != "" {
+		t.Errorf("Expected a context with its own api.url not to inherit api.token, got '%s'", got)
+	}
+	if got := v.GetString("default.namespace"); got != "staging" {
+		t.Errorf("Expected namespace from context, got '%s'", got)
+	}
+}
+
+func TestApplyExplicitContextOverridesCurrent(t *testing.T) {
+	v := newTestViper(t, testContextsConfig)
+	v.Set(ContextKey, "prod")
+
+	name, err := applyContext(v)
+	if err != nil {
+		t.Fatalf("Failed to apply context: %v", err)
+	}
+	if name != "prod" {
+		t.Errorf("Expected context 'prod', got '%s'", name)
+	}
+	if !v.GetBool("api.insecure") {
+		t.Error("Expected api.insecure from prod context")
+	}
+	if got := v.GetString("default.namespace"); got != "top" {
+		t.Errorf("Expected namespace to fall back to top level, got '%s'", got)
+	}
+}
+
+func TestContextReplacesCredentials(t *testing.T) {
+	v := newTestViper(t, `
+api:
+  url: http://dev
+  token: dev-token
+  clientCert: dev.crt
+  tokenExec:
+    command: vault
+    env:
+      VAULT_TOKEN: dev
+current-context: prod
+contexts:
+  - name: prod
+    api:
+      url: http://prod
+      retries: 5
+`)
+
+	cfg, err := loadConfig(v)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.API.URL != "http://prod" || cfg.API.Retries != 5 {
+		t.Errorf("Expected prod api settings, got url '%s', retries %d", cfg.API.URL, cfg.API.Retries)
+	}
+	if cfg.API.Token != "" || cfg.API.ClientCert != "" || cfg.API.TokenExec != nil {
+		t.Errorf("Expected prod not to inherit top-level credentials, got token '%s', clientCert '%s', tokenExec %+v",
+			cfg.API.Token, cfg.API.ClientCert, cfg.API.TokenExec)
+	}
+
+	// A context that sets neither the URL nor credentials keeps them
+	v = newTestViper(t, `
+api:
+  url: http://dev
+  token: dev-token
+contexts:
+  - name: dev-tls
+    api:
+      insecure: true
+`)
+	v.Set(ContextKey, "dev-tls")
+	cfg, err = loadConfig(v)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.API.URL != "http://dev" || cfg.API.Token != "dev-token" || !cfg.API.Insecure {
+		t.Errorf("Expected dev-tls to extend the top level, got %+v", cfg.API)
+	}
+}
+
+func TestContextCredentialsReplaceTopLevel(t *testing.T) {
+	v := newTestViper(t, `
+api:
+  url: http://dev
+  tokenExec:
+    command: vault
+    env:
+      VAULT_TOKEN: dev
+contexts:
+  - name: prod
+    api:
+      url: http://prod
+      tokenExec:
+        command: prod-helper
+`)
+	v.Set(ContextKey, "prod")
+
+	cfg, err := loadConfig(v)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if exec := cfg.API.TokenExec; exec == nil || exec.Command != "prod-helper" || len(exec.Env) != 0 {
+		t.Errorf("Expected only the prod tokenExec, got %+v", exec)
+	}
+}
+
+func TestApplyUnknownContext(t *testing.T) {
+	v := newTestViper(t, testContextsConfig)
+	v.Set(ContextKey, "missing")
+
+	_, err := applyContext(v)
+	if !errors.Is(err, ErrContextNotFound) {
+		t.Errorf("Expected ErrContextNotFound, got %v", err)
+	}
+}
+
+func TestApplyNoContext(t *testing.T) {
+	v := newTestViper(t, "api:\n  url: http://top\n")
+
+	name, err := applyContext(v)
+	if err != nil || name != "" {
+		t.Errorf("Expected no context to be applied, got '%s', %v", name, err)
+	}
+	if got := v.GetString("api.url"); got != "http://top" {
+		t.Errorf("Expected top-level api.url, got '%s'", got)
+	}
+}
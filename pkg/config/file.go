@@ -0,0 +1,336 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File is a configuration file loaded for editing. It works on the YAML node
+// tree so that comments, key order and unknown keys survive a round trip.
+type File struct {
+	Path string
+	doc  *yaml.Node
+}
+
+// LoadFile reads the configuration file at path. A missing file yields an
+// empty configuration that is created on Save.
+func LoadFile(path string) (*File, error) {
+	f := &File{Path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	var doc yaml.Node
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse configuration file %s: %w", path, err)
+		}
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("configuration file %s must contain a YAML mapping", path)
+	}
+
+	f.doc = &doc
+	return f, nil
+}
+
+// Save writes the configuration file, creating its directory if needed. The
+// file may contain tokens, so it is only readable by the owner.
+func (f *File) Save() error {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(f.doc); err != nil {
+		return fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to encode configuration: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(f.Path, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write configuration file: %w", err)
+	}
+	return nil
+}
+
+// root returns the top-level mapping node
+func (f *File) root() *yaml.Node {
+	return f.doc.Content[0]
+}
+
+// CurrentContext returns the current-context value
+func (f *File) CurrentContext() string {
+	if node := lookup(f.root(), "current-context"); node != nil {
+		return node.Value
+	}
+	return ""
+}
+
+// SetCurrentContext sets current-context, or removes it when name is empty
+func (f *File) SetCurrentContext(name string) {
+	if name == "" {
+		removeKey(f.root(), "current-context")
+		return
+	}
+	setScalar(f.root(), []string{"current-context"}, name, "!!str")
+}
+
+// Get returns the value of a setting, e.g. "api.url", and whether it is set
+func (f *File) Get(key string) (string, bool, error) {
+	if _, err := lookupSetting(key); err != nil {
+		return "", false, err
+	}
+	node := f.root()
+	for _, part := range strings.Split(key, ".") {
+		if node = lookup(node, part); node == nil {
+			return "", false, nil
+		}
+	}
+	if node.Kind != yaml.ScalarNode {
+		return "", false, fmt.Errorf("%s is not a scalar value", key)
+	}
+	return node.Value, true, nil
+}
+
+// Set validates value and writes it to the setting key
+func (f *File) Set(key, value string) error {
+	s, err := lookupSetting(key)
+	if err != nil {
+		return err
+	}
+	if value, err = s.normalize(value); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	setScalar(f.root(), strings.Split(key, "."), value, s.tag)
+	return nil
+}
+
+// Unset removes the setting key and reports whether it was set. Sections
+// left empty are removed as well.
+func (f *File) Unset(key string) (bool, error) {
+	if _, err := lookupSetting(key); err != nil {
+		return false, err
+	}
+	return removePath(f.root(), strings.Split(key, ".")), nil
+}
+
+// Contexts decodes the contexts list
+func (f *File) Contexts() ([]Context, error) {
+	node := lookup(f.root(), "contexts")
+	if node == nil {
+		return nil, nil
+	}
+
+	var raw []contextYAML
+	if err := node.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid contexts list: %w", err)
+	}
+
+	contexts := make([]Context, 0, len(raw))
+	for _, ctx := range raw {
+		contexts = append(contexts, ctx.toContext())
+	}
+	return contexts, nil
+}
+
+// HasContext reports whether a context with the given name exists
+func (f *File) HasContext(name string) bool {
+	return f.contextNode(name) != nil
+}
+
+// SetContext creates or updates the named context. Values are keyed by
+// dotted paths relative to the context, e.g. "api.url" or "default.namespace",
+// and are validated like Set.
+func (f *File) SetContext(name string, values map[string]string) error {
+	normalized := make(map[string]string, len(values))
+	for path, value := range values {
+		s, err := lookupSetting(path)
+		if err != nil || !s.inContext {
+			return fmt.Errorf("%s cannot be set in a context", path)
+		}
+		if normalized[path], err = s.normalize(value); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", path, err)
+		}
+	}
+
+	ctx := f.contextNode(name)
+	if ctx == nil {
+		list := lookup(f.root(), "contexts")
+		if list == nil || list.Kind != yaml.SequenceNode {
+			list = &yaml.Node{Kind: yaml.SequenceNode}
+			setNode(f.root(), "contexts", list)
+		}
+		ctx = &yaml.Node{Kind: yaml.MappingNode}
+		setScalar(ctx, []string{"name"}, name, "!!str")
+		list.Content = append(list.Content, ctx)
+	}
+
+	for _, path := range sortedKeys(normalized) {
+		setScalar(ctx, strings.Split(path, "."), normalized[path], settings[path].tag)
+	}
+	return nil
+}
+
+// DeleteContext removes the named context and reports whether it existed
+func (f *File) DeleteContext(name string) bool {
+	list := lookup(f.root(), "contexts")
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return false
+	}
+	for i, item := range list.Content {
+		if contextName(item) == name {
+			list.Content = append(list.Content[:i], list.Content[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// contextNode returns the mapping node of the named context
+func (f *File) contextNode(name string) *yaml.Node {
+	list := lookup(f.root(), "contexts")
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return nil
+	}
+	for _, item := range list.Content {
+		if contextName(item) == name {
+			return item
+		}
+	}
+	return nil
+}
+
+// contextName returns the name of a context mapping node
+func contextName(node *yaml.Node) string {
+	if node.Kind != yaml.MappingNode {
+		return ""
+	}
+	if name := lookup(node, "name"); name != nil {
+		return name.Value
+	}
+	return ""
+}
+
+// contextYAML mirrors Context with yaml tags for decoding file contents
+type contextYAML struct {
+	Name string `yaml:"name"`
+	API  struct {
+		URL      string `yaml:"url"`
+		Token    string `yaml:"token"`
+		Insecure bool   `yaml:"insecure"`
+	} `yaml:"api"`
+	Default struct {
+		Namespace string `yaml:"namespace"`
+	} `yaml:"default"`
+	Output struct {
+		Format string `yaml:"format"`
+	} `yaml:"output"`
+}
+
+func (c contextYAML) toContext() Context {
+	return Context{
+		Name:    c.Name,
+		API:     APIConfig{URL: c.API.URL, Token: c.API.Token, Insecure: c.API.Insecure},
+		Default: DefaultConfig{Namespace: c.Default.Namespace},
+		Output:  OutputConfig{Format: c.Output.Format},
+	}
+}
+
+// lookup returns the value node for key in a mapping node
+func lookup(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setNode sets key to value in a mapping node, appending the key if missing
+func setNode(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Value: key},
+		value)
+}
+
+// setScalar sets the scalar at path with the given tag, creating intermediate
+// mappings. An existing scalar keeps its position and comments.
+func setScalar(mapping *yaml.Node, path []string, value, tag string) {
+	for _, key := range path[:len(path)-1] {
+		next := lookup(mapping, key)
+		if next == nil || next.Kind != yaml.MappingNode {
+			next = &yaml.Node{Kind: yaml.MappingNode}
+			setNode(mapping, key, next)
+		}
+		mapping = next
+	}
+
+	key := path[len(path)-1]
+	if existing := lookup(mapping, key); existing != nil && existing.Kind == yaml.ScalarNode {
+		existing.Value = value
+		existing.Tag = tag
+		existing.Style = 0
+		return
+	}
+	setNode(mapping, key, &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value})
+}
+
+// removeKey deletes key from a mapping node and reports whether it existed
+func removeKey(mapping *yaml.Node, key string) bool {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// removePath deletes the value at path and prunes mappings left empty
+func removePath(mapping *yaml.Node, path []string) bool {
+	if len(path) == 1 {
+		return removeKey(mapping, path[0])
+	}
+	child := lookup(mapping, path[0])
+	if child == nil || child.Kind != yaml.MappingNode {
+		return false
+	}
+	removed := removePath(child, path[1:])
+	if removed && len(child.Content) == 0 {
+		removeKey(mapping, path[0])
+	}
+	return removed
+}
+
+// sortedKeys returns the keys of m in a stable order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,220 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testConfigFile = `# vvp2 configuration
+api:
+  url: http://vvp.localhost # local install
+  token: ""
+default:
+  namespace: default
+custom:
+  keep: me
+`
+
+func writeTestConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestFileSetContextPreservesContent(t *testing.T) {
+	path := writeTestConfig(t, testConfigFile)
+
+	file, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to load file: %v", err)
+	}
+	file.SetContext("prod", map[string]string{
+		"api.url":           "https://vvp.example.com",
+		"api.insecure":      "true",
+		"api.token":         "12345",
+		"default.namespace": "analytics",
+	})
+	file.SetCurrentContext("prod")
+	if err := file.Save(); err != nil {
+		t.Fatalf("Failed to save file: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	content := string(data)
+	for _, want := range []string{
+		"# vvp2 configuration",
+		"# local install",
+		"keep: me",
+		"current-context: prod",
+		"insecure: true",
+		`token: "12345"`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected saved file to contain %q, got:\n%s", want, content)
+		}
+	}
+
+	file, err = LoadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to reload file: %v", err)
+	}
+	contexts, err := file.Contexts()
+	if err != nil {
+		t.Fatalf("Failed to decode contexts: %v", err)
+	}
+	if len(contexts) != 1 {
+		t.Fatalf("Expected 1 context, got %d", len(contexts))
+	}
+	ctx := contexts[0]
+	if ctx.Name != "prod" || ctx.API.URL != "https://vvp.example.com" || !ctx.API.Insecure || ctx.API.Token != "12345" || ctx.Default.Namespace != "analytics" {
+		t.Errorf("Unexpected context: %+v", ctx)
+	}
+}
+
+func TestFileUpdateAndDeleteContext(t *testing.T) {
+	file, err := LoadFile(filepath.Join(t.TempDir(), "missing", "config.yaml"))
+	if err != nil {
+		t.Fatalf("Expected missing file to load empty, got error: %v", err)
+	}
+
+	file.SetContext("dev", map[string]string{"api.url": "http://dev"})
+	file.SetContext("dev", map[string]string{"output.format": "json"})
+	file.SetContext("staging", nil)
+
+	contexts, _ := file.Contexts()
+	if len(contexts) != 2 {
+		t.Fatalf("Expected 2 contexts, got %d", len(contexts))
+	}
+	if contexts[0].API.URL != "http://dev" || contexts[0].Output.Format != "json" {
+		t.Errorf("Expected update to merge fields, got %+v", contexts[0])
+	}
+
+	if !file.DeleteContext("dev") {
+		t.Error("Expected dev context to be deleted")
+	}
+	if file.DeleteContext("dev") {
+		t.Error("Expected second delete to report missing context")
+	}
+	if file.HasContext("dev") || !file.HasContext("staging") {
+		t.Error("Expected only staging context to remain")
+	}
+
+	if err := file.Save(); err != nil {
+		t.Fatalf("Failed to save file: %v", err)
+	}
+	info, err := os.Stat(file.Path)
+	if err != nil {
+		t.Fatalf("Expected file to be created: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected file mode 0600, got %v", info.Mode().Perm())
+	}
+}
+
+func TestFileSetGetUnset(t *testing.T) {
+	path := writeTestConfig(t, testConfigFile)
+	file, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to load file: %v", err)
+	}
+
+	if err := file.Set("api.url", "https://vvp.example.com/"); err != nil {
+		t.Fatalf("Failed to set api.url: %v", err)
+	}
+	if err := file.Set("output.format", "YAML"); err != nil {
+		t.Fatalf("Failed to set output.format: %v", err)
+	}
+	if err := file.Set("api.retryWaitTime", "1500ms"); err != nil {
+		t.Fatalf("Failed to set api.retryWaitTime: %v", err)
+	}
+
+	tests := map[string]string{
+		"api.url":           "https://vvp.example.com",
+		"output.format":     "yaml",
+		"api.retryWaitTime": "1.5s",
+		"default.namespace": "default",
+	}
+	for key, want := range tests {
+		got, ok, err := file.Get(key)
+		if err != nil || !ok || got != want {
+			t.Errorf("Get(%s): expected '%s', got '%s' (set: %v, err: %v)", key, want, got, ok, err)
+		}
+	}
+
+	if removed, _ := file.Unset("default.namespace"); !removed {
+		t.Error("Expected default.namespace to be removed")
+	}
+	if removed, _ := file.Unset("default.namespace"); removed {
+		t.Error("Expected second unset to report missing key")
+	}
+	if err := file.Save(); err != nil {
+		t.Fatalf("Failed to save file: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	content := string(data)
+	if strings.Contains(content, "default:") {
+		t.Errorf("Expected empty default section to be pruned, got:\n%s", content)
+	}
+	if !strings.Contains(content, "url: https://vvp.example.com # local install") {
+		t.Errorf("Expected comment on api.url to be preserved, got:\n%s", content)
+	}
+	if !strings.Contains(content, "keep: me") {
+		t.Errorf("Expected unknown keys to be preserved, got:\n%s", content)
+	}
+}
+
+func TestFileSetValidation(t *testing.T) {
+	file, err := LoadFile(filepath.Join(t.TempDir(), "config.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to load file: %v", err)
+	}
+
+	invalid := map[string]string{
+		"output.format":     "xml",
+		"api.url":           "vvp.example.com",
+		"api.insecure":      "maybe",
+		"api.retries":       "-1",
+		"api.retryWaitTime": "soon",
+		"unknown.key":       "value",
+	}
+	for key, value := range invalid {
+		if err := file.Set(key, value); err == nil {
+			t.Errorf("Expected Set(%s, %s) to fail", key, value)
+		}
+	}
+
+	if err := file.SetContext("dev", map[string]string{"api.retries": "3"}); err == nil {
+		t.Error("Expected api.retries to be rejected in a context")
+	}
+}
+
+func TestFileSetOutputFormat(t *testing.T) {
+	file, err := LoadFile(filepath.Join(t.TempDir(), "config.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to load file: %v", err)
+	}
+
+	tests := map[string]string{
+		"Name":                               "name",
+		"Custom-Columns=NAME:.metadata.name": "custom-columns=NAME:.metadata.name",
+		"jsonpath={.items[*].metadata.name}": "jsonpath={.items[*].metadata.name}",
+	}
+	for value, want := range tests {
+		if err := file.Set("output.format", value); err != nil {
+			t.Fatalf("Failed to set output.format to %s: %v", value, err)
+		}
+		if got, _, _ := file.Get("output.format"); got != want {
+			t.Errorf("Set(output.format, %s): expected '%s', got '%s'", value, want, got)
+		}
+	}
+
+	if err := file.Set("output.format", "jsonpath="); err == nil {
+		t.Error("Expected jsonpath without a template to be rejected")
+	}
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// setting describes a configuration key that can be edited with config set
+type setting struct {
+	tag       string // YAML tag used when writing the value
+	inContext bool   // whether the key may also be set inside a context
+	normalize func(string) (string, error)
+}
+
+// settings lists the keys supported by config set/get/unset
+var settings = map[string]setting{
+	"api.url":                  {tag: "!!str", inContext: true, normalize: normalizeURL},
+	"api.token":                {tag: "!!str", inContext: true, normalize: normalizeString},
+	"api.insecure":             {tag: "!!bool", inContext: true, normalize: normalizeBool},
+	"api.caFile":               {tag: "!!str", inContext: true, normalize: normalizeString},
+	"api.clientCert":           {tag: "!!str", inContext: true, normalize: normalizeString},
+	"api.clientKey":            {tag: "!!str", inContext: true, normalize: normalizeString},
+	"api.proxy":                {tag: "!!str", inContext: true, normalize: normalizeURL},
+	"api.noProxy":              {tag: "!!str", inContext: true, normalize: normalizeString},
+	"api.tokenFile":            {tag: "!!str", inContext: true, normalize: normalizeString},
+	"api.tokenExec.command":    {tag: "!!str", inContext: true, normalize: normalizeString},
+	"api.tokenKeyring.service": {tag: "!!str", inContext: true, normalize: normalizeString},
+	"api.tokenKeyring.account": {tag: "!!str", inContext: true, normalize: normalizeString},
+	"api.oidc.issuer":          {tag: "!!str", inContext: true, normalize: normalizeURL},
+	"api.oidc.clientId":        {tag: "!!str", inContext: true, normalize: normalizeString},
+	"api.oidc.clientSecret":    {tag: "!!str", inContext: true, normalize: normalizeString},
+	"api.oidc.audience":        {tag: "!!str", inContext: true, normalize: normalizeString},
+	"api.retries":              {tag: "!!int", normalize: normalizeRetries},
+	"api.retryWaitTime":        {tag: "!!str", normalize: normalizeDuration},
+	"api.retryMaxWaitTime":     {tag: "!!str", normalize: normalizeDuration},
+	"api.retryAllMethods":      {tag: "!!bool", normalize: normalizeBool},
+	"default.namespace":        {tag: "!!str", inContext: true, normalize: normalizeString},
+	"output.format":            {tag: "!!str", inContext: true, normalize: normalizeOutputFormat},
+	"current-context":          {tag: "!!str", normalize: normalizeString},
+}
+
+// SettingKeys returns the keys supported by config set/get/unset
+func SettingKeys() []string {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// lookupSetting returns the setting for key
+func lookupSetting(key string) (setting, error) {
+	s, ok := settings[key]
+	if !ok {
+		return setting{}, fmt.Errorf("unknown setting %q (supported: %s)", key, strings.Join(SettingKeys(), ", "))
+	}
+	return s, nil
+}
+
+func normalizeString(value string) (string, error) {
+	return value, nil
+}
+
+func normalizeURL(value string) (string, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return "", err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("%q must be an http:// or https:// URL", value)
+	}
+	return strings.TrimSuffix(value, "/"), nil
+}
+
+func normalizeBool(value string) (string, error) {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return "", fmt.Errorf("%q is not a boolean (true or false)", value)
+	}
+	return strconv.FormatBool(b), nil
+}
+
+func normalizeRetries(value string) (string, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return "", fmt.Errorf("%q must be a non-negative integer", value)
+	}
+	return strconv.Itoa(n), nil
+}
+
+func normalizeDuration(value string) (string, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return "", fmt.Errorf("%q is not a valid duration (e.g. 500ms, 2s)", value)
+	}
+	return d.String(), nil
+}
+
+// Output format names accepted for output.format, matching the -o flag. The
+// template formats require an argument, as in jsonpath={.metadata.name};
+// the argument itself is checked when output is printed.
+var (
+	OutputFormats         = []string{"table", "json", "yaml", "name"}
+	TemplateOutputFormats = []string{"jsonpath", "go-template", "custom-columns"}
+)
+
+// normalizeOutputFormat accepts any -o value, lower-casing the format name
+// but not its template
+func normalizeOutputFormat(value string) (string, error) {
+	name, arg, hasArg := strings.Cut(value, "=")
+	name = strings.ToLower(name)
+	switch {
+	case contains(OutputFormats, name):
+		if hasArg {
+			return "", fmt.Errorf("output format %s does not take an argument", name)
+		}
+		return name, nil
+	case contains(TemplateOutputFormats, name):
+		if arg == "" {
+			return "", fmt.Errorf("output format %s requires an argument, as in %s=...", name, name)
+		}
+		return name + "=" + arg, nil
+	}
+	return "", fmt.Errorf("%q must be one of %s, or %s=...", value, strings.Join(OutputFormats, ", "), strings.Join(TemplateOutputFormats, "=..., "))
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultContext is the number of unchanged lines shown around each change
+const DefaultContext = 3
+
+// opKind identifies a line-level edit operation
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+// edit is a single line of an edit script
+type edit struct {
+	kind opKind
+	line string
+}
+
+// Unified returns a unified diff between a and b, or an empty string when
+// they are equal. fromName and toName label the two sides in the header.
+func Unified(a, b, fromName, toName string, context int) string {
+	if a == b {
+		return ""
+	}
+
+	edits := lineEdits(splitLines(a), splitLines(b))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n", fromName)
+	fmt.Fprintf(&sb, "+++ %s\n", toName)
+	for _, h := range hunks(edits, context) {
+		sb.WriteString(h)
+	}
+	return sb.String()
+}
+
+// splitLines splits text into lines, dropping the final empty line
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// lineEdits computes a minimal edit script using the longest common subsequence
+func lineEdits(a, b []string) []edit {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	edits := make([]edit, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			edits = append(edits, edit{opEqual, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, edit{opDelete, a[i]})
+			i++
+		default:
+			edits = append(edits, edit{opInsert, b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		edits = append(edits, edit{opDelete, a[i]})
+	}
+	for ; j < m; j++ {
+		edits = append(edits, edit{opInsert, b[j]})
+	}
+	return edits
+}
+
+// hunks groups an edit script into unified diff hunks
+func hunks(edits []edit, context int) []string {
+	var out []string
+
+	for start := 0; start < len(edits); {
+		// Find the next change
+		first := start
+		for first < len(edits) && edits[first].kind == opEqual {
+			first++
+		}
+		if first == len(edits) {
+			break
+		}
+
+		// Extend the hunk while changes are within 2*context lines of each other
+		last := first
+		for k := first; k < len(edits); k++ {
+			if edits[k].kind != opEqual {
+				last = k
+			} else if k-last > 2*context {
+				break
+			}
+		}
+
+		from := max(first-context, 0)
+		to := min(last+context+1, len(edits))
+
+		// Line numbers are 1-based positions in each input
+		aStart, bStart := 1, 1
+		for _, e := range edits[:from] {
+			if e.kind != opInsert {
+				aStart++
+			}
+			if e.kind != opDelete {
+				bStart++
+			}
+		}
+
+		var body strings.Builder
+		aLen, bLen := 0, 0
+		for _, e := range edits[from:to] {
+			switch e.kind {
+			case opEqual:
+				body.WriteString(" " + e.line + "\n")
+				aLen++
+				bLen++
+			case opDelete:
+				body.WriteString("-" + e.line + "\n")
+				aLen++
+			case opInsert:
+				body.WriteString("+" + e.line + "\n")
+				bLen++
+			}
+		}
+
+		// An empty side starts at the line before, per the unified format
+		if aLen == 0 {
+			aStart--
+		}
+		if bLen == 0 {
+			bStart--
+		}
+		out = append(out, fmt.Sprintf("@@ -%d,%d +%d,%d @@\n%s", aStart, aLen, bStart, bLen, body.String()))
+		start = to
+	}
+	return out
+}
+
+// Colorize adds ANSI colors to a unified diff: removals in red, additions
+// in green and hunk headers in cyan. ---/+++ lines are file headers only
+// outside hunks; inside a hunk they are removed or added lines whose content
+// starts with -- or ++ (e.g. SQL comments).
+func Colorize(unified string) string {
+	const (
+		red   = "\x1b[31m"
+		green = "\x1b[32m"
+		cyan  = "\x1b[36m"
+		bold  = "\x1b[1m"
+		reset = "\x1b[0m"
+	)
+
+	// Lines of the current hunk still to come on the old and new side
+	var oldLeft, newLeft int
+	lines := splitLines(unified)
+	for i, line := range lines {
+		inHunk := oldLeft > 0 || newLeft > 0
+		switch {
+		case !inHunk && (strings.HasPrefix(line, "---") || strings.HasPrefix(line, "+++")):
+			lines[i] = bold + line + reset
+		case !inHunk && strings.HasPrefix(line, "@@"):
+			oldLeft, newLeft = hunkLengths(line)
+			lines[i] = cyan + line + reset
+		case strings.HasPrefix(line, "-"):
+			oldLeft--
+			lines[i] = red + line + reset
+		case strings.HasPrefix(line, "+"):
+			newLeft--
+			lines[i] = green + line + reset
+		case strings.HasPrefix(line, " "):
+			oldLeft--
+			newLeft--
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// hunkLengths returns the old and new line counts of a hunk header such as
+// "@@ -1,3 +1,4 @@". An omitted count means one line.
+func hunkLengths(header string) (int, int) {
+	var oldLen, newLen int
+	for _, field := range strings.Fields(header) {
+		if len(field) < 2 || (field[0] != '-' && field[0] != '+') {
+			continue
+		}
+		n := 1
+		if _, count, found := strings.Cut(field[1:], ","); found {
+			n, _ = strconv.Atoi(count)
+		}
+		if field[0] == '-' {
+			oldLen = n
+		} else {
+			newLen = n
+		}
+	}
+	return oldLen, newLen
+}
@@ -0,0 +1,84 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedEqual(t *testing.T) {
+	if got := Unified("a\nb\n", "a\nb\n", "live", "local", DefaultContext); got != "" {
+		t.Errorf("Expected empty diff for equal input, got %q", got)
+	}
+}
+
+func TestUnifiedChange(t *testing.T) {
+	a := "metadata:\n  name: job\nspec:\n  state: RUNNING\n  parallelism: 1\n"
+	b := "metadata:\n  name: job\nspec:\n  state: RUNNING\n  parallelism: 2\n"
+
+	got := Unified(a, b, "live/Deployment/job", "local/Deployment/job", DefaultContext)
+	want := `--- live/Deployment/job
++++ local/Deployment/job
+@@ -2,4 +2,4 @@
+   name: job
+ spec:
+   state: RUNNING
+-  parallelism: 1
++  parallelism: 2
+`
+	if got != want {
+		t.Errorf("Unexpected diff:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestUnifiedNewFile(t *testing.T) {
+	got := Unified("", "a\nb\n", "live", "local", DefaultContext)
+	if !strings.Contains(got, "@@ -0,0 +1,2 @@\n+a\n+b\n") {
+		t.Errorf("Expected whole-file insertion hunk, got:\n%s", got)
+	}
+}
+
+func TestUnifiedSeparateHunks(t *testing.T) {
+	var a, b []string
+	for i := 0; i < 20; i++ {
+		line := string(rune('a' + i))
+		a = append(a, line)
+		b = append(b, line)
+	}
+	b[1] = "changed-1"
+	b[18] = "changed-18"
+
+	got := Unified(strings.Join(a, "\n")+"\n", strings.Join(b, "\n")+"\n", "live", "local", DefaultContext)
+	if n := strings.Count(got, "@@ -"); n != 2 {
+		t.Errorf("Expected 2 hunks, got %d:\n%s", n, got)
+	}
+}
+
+func TestColorize(t *testing.T) {
+	got := Colorize("--- a\n+++ b\n@@ -1,1 +1,1 @@\n-old\n+new\n")
+	if !strings.Contains(got, "\x1b[31m-old\x1b[0m") {
+		t.Errorf("Expected removal in red, got %q", got)
+	}
+	if !strings.Contains(got, "\x1b[32m+new\x1b[0m") {
+		t.Errorf("Expected addition in green, got %q", got)
+	}
+}
+
+func TestColorizeDashedContent(t *testing.T) {
+	// Removed and added SQL comments look like file headers
+	unified := Unified("SELECT 1\n-- old comment\nFROM t\n", "SELECT 1\n++ new\nFROM t\n", "live", "local", DefaultContext) +
+		"--- live2\n+++ local2\n@@ -1 +1 @@\n-a\n+b\n"
+	got := Colorize(unified)
+	for _, want := range []string{
+		"\x1b[1m--- live\x1b[0m",
+		"\x1b[1m+++ local\x1b[0m",
+		"\x1b[31m--- old comment\x1b[0m",
+		"\x1b[32m+++ new\x1b[0m",
+		"\x1b[1m--- live2\x1b[0m",
+		"\x1b[36m@@ -1 +1 @@\x1b[0m",
+		"\x1b[31m-a\x1b[0m",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in %q", want, got)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package labels
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     string
+	}{
+		{"", ""},
+		{"team=data-platform", "team=data-platform"},
+		{"team==data-platform", "team=data-platform"},
+		{" team = data , type != adhoc ", "team=data,type!=adhoc"},
+		{"env in (staging, prod),tier notin (test)", "env in (prod,staging),tier notin (test)"},
+		{"owner,!legacy", "owner,!legacy"},
+		{"app.kubernetes.io/name=orders", "app.kubernetes.io/name=orders"},
+		{"optional=", "optional="},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.selector)
+		if err != nil {
+			t.Errorf("Parse(%q): unexpected error: %v", tt.selector, err)
+			continue
+		}
+		if got := s.String(); got != tt.want {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.selector, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, selector := range []string{
+		"team=data,",
+		"=data",
+		"team=a=b",
+		"team in (a,b",
+		"team in (a b)",
+		"!",
+		"bad key=x",
+	} {
+		if _, err := Parse(selector); err == nil {
+			t.Errorf("Parse(%q): expected error", selector)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	labels := map[string]string{"team": "data-platform", "type": "streaming", "env": "prod"}
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{"", true},
+		{"team=data-platform", true},
+		{"team=other", false},
+		{"team=data-platform,type!=adhoc", true},
+		{"type!=streaming", false},
+		{"missing!=x", true},
+		{"env in (prod,staging)", true},
+		{"env in (dev)", false},
+		{"missing in (x)", false},
+		{"env notin (dev,test)", true},
+		{"env notin (prod)", false},
+		{"missing notin (x)", true},
+		{"team", true},
+		{"missing", false},
+		{"!missing", true},
+		{"!team", false},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.selector)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.selector, err)
+		}
+		if got := s.Matches(labels); got != tt.want {
+			t.Errorf("%q matches %v: got %v, want %v", tt.selector, labels, got, tt.want)
+		}
+	}
+
+	if s, _ := Parse("!team"); !s.Matches(nil) {
+		t.Error("Expected !team to match a resource without labels")
+	}
+}
@@ -0,0 +1,106 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// serverManagedMetadata lists metadata fields populated by the platform
+var serverManagedMetadata = []string{"id", "createdAt", "modifiedAt", "resourceVersion"}
+
+// Normalize converts an API object into a generic JSON map and strips
+// server-managed fields (metadata timestamps, ids, resource versions and
+// status) as well as the apiVersion/kind envelope.
+func Normalize(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode object: %w", err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to decode object: %w", err)
+	}
+
+	delete(m, "apiVersion")
+	delete(m, "kind")
+	delete(m, "status")
+	if meta, ok := m["metadata"].(map[string]interface{}); ok {
+		for _, field := range serverManagedMetadata {
+			delete(meta, field)
+		}
+	}
+	return m, nil
+}
+
+// Prune returns v restricted to the map keys present in shape. Lists of
+// equal length are pruned element by element; anything else is kept as is.
+func Prune(v, shape interface{}) interface{} {
+	switch s := shape.(type) {
+	case map[string]interface{}:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return v
+		}
+		out := make(map[string]interface{}, len(s))
+		for k, sv := range s {
+			if val, found := m[k]; found {
+				out[k] = Prune(val, sv)
+			}
+		}
+		return out
+	case []interface{}:
+		l, ok := v.([]interface{})
+		if !ok || len(l) != len(s) {
+			return v
+		}
+		out := make([]interface{}, len(l))
+		for i := range l {
+			out[i] = Prune(l[i], s[i])
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// Comparable returns the desired and live objects reduced to the fields the
+// manifest actually specifies, ready for comparison or diffing. The desired
+// object is the typed value decoded from obj, so fields the client would not
+// send are ignored; live may be nil when the resource does not exist.
+func Comparable(obj *Object, desired, live interface{}) (want, got map[string]interface{}, err error) {
+	raw, err := obj.Map()
+	if err != nil {
+		return nil, nil, err
+	}
+	raw, err = Normalize(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	typed, err := Normalize(desired)
+	if err != nil {
+		return nil, nil, err
+	}
+	want, _ = Prune(typed, raw).(map[string]interface{})
+
+	if live == nil {
+		return want, nil, nil
+	}
+	current, err := Normalize(live)
+	if err != nil {
+		return nil, nil, err
+	}
+	got, _ = Prune(current, want).(map[string]interface{})
+	return want, got, nil
+}
+
+// Equal reports whether the live object already matches the manifest
+func Equal(obj *Object, desired, live interface{}) (bool, error) {
+	want, got, err := Comparable(obj, desired, live)
+	if err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(want, got), nil
+}
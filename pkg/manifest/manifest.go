@@ -0,0 +1,161 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Resource kinds understood by manifest-driven commands
+const (
+	KindNamespace          = "Namespace"
+	KindDeploymentTarget   = "DeploymentTarget"
+	KindSecretValue        = "SecretValue"
+	KindSessionCluster     = "SessionCluster"
+	KindSession            = "Session"
+	KindDeployment         = "Deployment"
+	KindDeploymentDefaults = "DeploymentDefaults"
+)
+
+// Object is a single resource document read from a manifest
+type Object struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Source    string
+	Data      []byte     // JSON encoding of the document
+	node      *yaml.Node // YAML document; nil when the input was JSON
+}
+
+// Decode parses a YAML or JSON document into an Object
+func Decode(data []byte, source string) (*Object, error) {
+	if json.Valid(data) {
+		return fromJSON(data, source)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: failed to parse file as JSON or YAML: %w", source, err)
+	}
+	content := documentContent(&doc)
+	if content == nil {
+		return nil, fmt.Errorf("%s: document is not an object", source)
+	}
+	return fromNode(content, source)
+}
+
+// Into decodes the object into the given API type. YAML documents are
+// decoded from their YAML nodes, so that unquoted scalars such as
+// flinkVersion: 1.20 fill string fields with their text as written.
+func (o *Object) Into(v interface{}) error {
+	var err error
+	if o.node != nil {
+		err = o.node.Decode(v)
+	} else {
+		err = json.Unmarshal(o.Data, v)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to decode %s: %w", o.Source, o.Kind, err)
+	}
+	return nil
+}
+
+// Map returns the object as a generic JSON map
+func (o *Object) Map() (map[string]interface{}, error) {
+	var m map[string]interface{}
+	if err := json.Unmarshal(o.Data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// String returns a short Kind/name reference for messages
+func (o *Object) String() string {
+	if o.Name == "" {
+		return o.Kind
+	}
+	return o.Kind + "/" + o.Name
+}
+
+// fromJSON creates an Object from a JSON document
+func fromJSON(data []byte, source string) (*Object, error) {
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil || m == nil {
+		return nil, fmt.Errorf("%s: document is not an object", source)
+	}
+	return newObject(m, data, source), nil
+}
+
+// fromNode creates an Object from a YAML mapping node
+func fromNode(node *yaml.Node, source string) (*Object, error) {
+	var doc interface{}
+	if err := node.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%s: failed to parse file as JSON or YAML: %w", source, err)
+	}
+	m, ok := toJSONValue(doc).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: document is not an object", source)
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to encode document: %w", source, err)
+	}
+	obj := newObject(m, data, source)
+	obj.node = node
+	return obj, nil
+}
+
+func newObject(m map[string]interface{}, data []byte, source string) *Object {
+	obj := &Object{Source: source, Data: data}
+	obj.Kind, _ = m["kind"].(string)
+	if meta, ok := m["metadata"].(map[string]interface{}); ok {
+		obj.Name, _ = meta["name"].(string)
+		obj.Namespace, _ = meta["namespace"].(string)
+	}
+	return obj
+}
+
+// documentContent returns the top-level node of a YAML document, or nil for
+// an empty document
+func documentContent(doc *yaml.Node) *yaml.Node {
+	node := doc
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		node = node.Content[0]
+	}
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == 0 || (node.Kind == yaml.ScalarNode && node.Tag == "!!null") {
+		return nil
+	}
+	return node
+}
+
+// toJSONValue converts values produced by the YAML decoder into values
+// encoding/json can marshal (YAML allows non-string map keys).
+func toJSONValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			t[k] = toJSONValue(val)
+		}
+		return t
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			m[fmt.Sprint(k)] = toJSONValue(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range t {
+			t[i] = toJSONValue(val)
+		}
+		return t
+	default:
+		return v
+	}
+}
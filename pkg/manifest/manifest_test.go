@@ -0,0 +1,236 @@
+package manifest
+
+import (
+	"testing"
+	"time"
+
+	"mcolomerc/vvp2cli/pkg/api"
+)
+
+func TestDecodeYAML(t *testing.T) {
+	yamlContent := `
+kind: Deployment
+apiVersion: v1
+metadata:
+  name: flink-play-job
+  namespace: default
+spec:
+  state: RUNNING
+  template:
+    spec:
+      parallelism: 2
+      artifact:
+        kind: JAR
+        jarUri: "http://example.com/app.jar"
+`
+
+	obj, err := Decode([]byte(yamlContent), "test.yaml")
+	if err != nil {
+		t.Fatalf("Failed to decode manifest: %v", err)
+	}
+
+	if obj.Kind != KindDeployment {
+		t.Errorf("Expected kind 'Deployment', got '%s'", obj.Kind)
+	}
+	if obj.Name != "flink-play-job" {
+		t.Errorf("Expected name 'flink-play-job', got '%s'", obj.Name)
+	}
+	if obj.Namespace != "default" {
+		t.Errorf("Expected namespace 'default', got '%s'", obj.Namespace)
+	}
+	if obj.String() != "Deployment/flink-play-job" {
+		t.Errorf("Expected reference 'Deployment/flink-play-job', got '%s'", obj.String())
+	}
+
+	var deployment api.Deployment
+	if err := obj.Into(&deployment); err != nil {
+		t.Fatalf("Failed to decode deployment: %v", err)
+	}
+	if deployment.Spec.Template.Spec.Parallelism != 2 {
+		t.Errorf("Expected parallelism 2, got %d", deployment.Spec.Template.Spec.Parallelism)
+	}
+	if deployment.Spec.Template.Spec.Artifact.JarURI != "http://example.com/app.jar" {
+		t.Errorf("Expected jarUri to be decoded, got '%s'", deployment.Spec.Template.Spec.Artifact.JarURI)
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	jsonContent := `{"kind": "SecretValue", "metadata": {"name": "mypassword"}, "spec": {"kind": "Plain", "value": "secret"}}`
+
+	obj, err := Decode([]byte(jsonContent), "test.json")
+	if err != nil {
+		t.Fatalf("Failed to decode manifest: %v", err)
+	}
+	if obj.Kind != KindSecretValue {
+		t.Errorf("Expected kind 'SecretValue', got '%s'", obj.Kind)
+	}
+	if obj.Namespace != "" {
+		t.Errorf("Expected empty namespace, got '%s'", obj.Namespace)
+	}
+}
+
+func TestDecodeUnquotedNumbers(t *testing.T) {
+	objs, err := DecodeAll([]byte(`
+kind: Deployment
+metadata:
+  name: job
+spec:
+  template:
+    spec:
+      flinkVersion: 1.20
+      flinkConfiguration:
+        taskmanager.numberOfTaskSlots: 2
+`), "test.yaml")
+	if err != nil {
+		t.Fatalf("Failed to decode manifest: %v", err)
+	}
+
+	var deployment api.Deployment
+	if err := objs[0].Into(&deployment); err != nil {
+		t.Fatalf("Failed to decode deployment: %v", err)
+	}
+	spec := deployment.Spec.Template.Spec
+	if spec.FlinkVersion != "1.20" {
+		t.Errorf("Expected flinkVersion '1.20', got '%s'", spec.FlinkVersion)
+	}
+	if slots := spec.FlinkConfiguration["taskmanager.numberOfTaskSlots"]; slots != "2" {
+		t.Errorf("Expected taskmanager.numberOfTaskSlots '2', got '%s'", slots)
+	}
+}
+
+func TestDecodeCamelCaseKeys(t *testing.T) {
+	obj, err := Decode([]byte(`
+kind: Namespace
+metadata:
+  name: team-a
+spec:
+  roleBindings:
+    - role: EDITOR
+      members: [user:alice]
+`), "test.yaml")
+	if err != nil {
+		t.Fatalf("Failed to decode manifest: %v", err)
+	}
+
+	var namespace api.Namespace
+	if err := obj.Into(&namespace); err != nil {
+		t.Fatalf("Failed to decode namespace: %v", err)
+	}
+	if len(namespace.Spec.RoleBindings) != 1 || namespace.Spec.RoleBindings[0].Role != "EDITOR" {
+		t.Errorf("Expected one EDITOR role binding, got %+v", namespace.Spec.RoleBindings)
+	}
+}
+
+func TestDecodeRejectsScalar(t *testing.T) {
+	if _, err := Decode([]byte("just a string"), "bad.yaml"); err == nil {
+		t.Error("Expected error for non-object document")
+	}
+}
+
+func TestEqualIgnoresServerFields(t *testing.T) {
+	obj, err := Decode([]byte(`
+kind: SecretValue
+metadata:
+  name: mypassword
+spec:
+  kind: Plain
+  value: secret
+`), "test.yaml")
+	if err != nil {
+		t.Fatalf("Failed to decode manifest: %v", err)
+	}
+
+	var desired api.SecretValue
+	if err := obj.Into(&desired); err != nil {
+		t.Fatalf("Failed to decode secret value: %v", err)
+	}
+
+	live := &api.SecretValue{
+		APIVersion: "v1",
+		Kind:       "SecretValue",
+		Metadata: api.SecretValueMetadata{
+			ID:              "1234",
+			Name:            "mypassword",
+			Namespace:       "default",
+			CreatedAt:       time.Now(),
+			ModifiedAt:      time.Now(),
+			ResourceVersion: 3,
+		},
+		Spec: api.SecretValueSpec{Kind: "Plain", Value: "secret"},
+	}
+
+	equal, err := Equal(obj, &desired, live)
+	if err != nil {
+		t.Fatalf("Equal failed: %v", err)
+	}
+	if !equal {
+		t.Error("Expected live object to match manifest")
+	}
+
+	live.Spec.Value = "changed"
+	equal, err = Equal(obj, &desired, live)
+	if err != nil {
+		t.Fatalf("Equal failed: %v", err)
+	}
+	if equal {
+		t.Error("Expected changed value to be detected")
+	}
+}
+
+func TestEqualUnquotedNumbers(t *testing.T) {
+	obj, err := Decode([]byte(`
+kind: Deployment
+metadata:
+  name: job
+spec:
+  template:
+    spec:
+      flinkVersion: 1.20
+      flinkConfiguration:
+        taskmanager.numberOfTaskSlots: 2
+`), "test.yaml")
+	if err != nil {
+		t.Fatalf("Failed to decode manifest: %v", err)
+	}
+
+	var desired api.Deployment
+	if err := obj.Into(&desired); err != nil {
+		t.Fatalf("Failed to decode deployment: %v", err)
+	}
+
+	live := &api.Deployment{Metadata: api.DeploymentMetadata{Name: "job", Namespace: "default"}}
+	live.Spec.Template.Spec.FlinkVersion = "1.20"
+	live.Spec.Template.Spec.FlinkConfiguration = map[string]string{"taskmanager.numberOfTaskSlots": "2"}
+
+	equal, err := Equal(obj, &desired, live)
+	if err != nil {
+		t.Fatalf("Equal failed: %v", err)
+	}
+	if !equal {
+		want, got, _ := Comparable(obj, &desired, live)
+		t.Errorf("Expected live object to match manifest, want %v, got %v", want, got)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	v := map[string]interface{}{
+		"a": "1",
+		"b": map[string]interface{}{"c": "2", "d": "3"},
+		"e": []interface{}{map[string]interface{}{"f": "4", "g": "5"}},
+	}
+	shape := map[string]interface{}{
+		"b": map[string]interface{}{"c": nil},
+		"e": []interface{}{map[string]interface{}{"f": nil}},
+	}
+
+	pruned := Prune(v, shape).(map[string]interface{})
+	if _, ok := pruned["a"]; ok {
+		t.Error("Expected key 'a' to be pruned")
+	}
+	if b := pruned["b"].(map[string]interface{}); len(b) != 1 || b["c"] != "2" {
+		t.Errorf("Expected only b.c to remain, got %v", b)
+	}
+	if e := pruned["e"].([]interface{})[0].(map[string]interface{}); len(e) != 1 || e["f"] != "4" {
+		t.Errorf("Expected only e[0].f to remain, got %v", e)
+	}
+}
@@ -0,0 +1,198 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Stdin is the path that selects standard input
+const Stdin = "-"
+
+// kindOrder is the order in which resources must be processed so that
+// every object's dependencies exist before it does.
+var kindOrder = []string{
+	KindNamespace,
+	KindDeploymentTarget,
+	KindSecretValue,
+	KindSessionCluster,
+	KindDeploymentDefaults,
+	KindDeployment,
+}
+
+// manifestExtensions are the file extensions read from directories
+var manifestExtensions = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// Read reads every object from a file, a directory of manifest files or,
+// when path is "-", standard input. Directories are only descended into
+// when recursive is set. Objects are returned in dependency order.
+func Read(path string, recursive bool) ([]*Object, error) {
+	var objs []*Object
+	var err error
+
+	if path == Stdin {
+		objs, err = ReadFrom(os.Stdin, "stdin")
+	} else {
+		objs, err = readPath(path, recursive)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(objs) == 0 {
+		return nil, fmt.Errorf("no objects found in %s", path)
+	}
+	Sort(objs)
+	return objs, nil
+}
+
+// ReadFrom reads every object from a stream of YAML documents, JSON
+// objects or JSON lists.
+func ReadFrom(r io.Reader, source string) ([]*Object, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", source, err)
+	}
+	return DecodeAll(data, source)
+}
+
+// DecodeAll parses multi-document YAML ("---" separated) or JSON, where any
+// document may also be a list of objects.
+func DecodeAll(data []byte, source string) ([]*Object, error) {
+	if json.Valid(data) {
+		return decodeJSON(data, source)
+	}
+
+	var objs []*Object
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for i := 1; ; i++ {
+		var doc yaml.Node
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("%s: failed to parse file as JSON or YAML: %w", source, err)
+		}
+
+		docSource := source
+		if i > 1 {
+			docSource = fmt.Sprintf("%s (document %d)", source, i)
+		}
+
+		content := documentContent(&doc)
+		switch {
+		case content == nil:
+			// Empty document, e.g. a trailing "---"
+		case content.Kind == yaml.SequenceNode:
+			for j, item := range content.Content {
+				obj, err := fromNode(item, fmt.Sprintf("%s[%d]", docSource, j))
+				if err != nil {
+					return nil, err
+				}
+				objs = append(objs, obj)
+			}
+		default:
+			obj, err := fromNode(content, docSource)
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, obj)
+		}
+	}
+
+	return objs, nil
+}
+
+// decodeJSON parses a JSON object or a JSON list of objects
+func decodeJSON(data []byte, source string) ([]*Object, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		obj, err := fromJSON(data, source)
+		if err != nil {
+			return nil, err
+		}
+		return []*Object{obj}, nil
+	}
+
+	objs := make([]*Object, 0, len(items))
+	for j, item := range items {
+		obj, err := fromJSON(item, fmt.Sprintf("%s[%d]", source, j))
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+// Sort orders objects by kind dependency (Namespace, DeploymentTarget,
+// SecretValue, SessionCluster, DeploymentDefaults, Deployment), keeping the
+// input order within a kind. Unknown kinds go last.
+func Sort(objs []*Object) {
+	sort.SliceStable(objs, func(i, j int) bool {
+		return kindRank(objs[i].Kind) < kindRank(objs[j].Kind)
+	})
+}
+
+func kindRank(kind string) int {
+	for i, k := range kindOrder {
+		if k == kind {
+			return i
+		}
+	}
+	return len(kindOrder)
+}
+
+func readPath(path string, recursive bool) ([]*Object, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if !info.IsDir() {
+		return readFile(path)
+	}
+
+	var objs []*Object
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != path && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !manifestExtensions[strings.ToLower(filepath.Ext(p))] {
+			return nil
+		}
+		fileObjs, err := readFile(p)
+		if err != nil {
+			return err
+		}
+		objs = append(objs, fileObjs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objs, nil
+}
+
+func readFile(filename string) ([]*Object, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return DecodeAll(data, filename)
+}
@@ -0,0 +1,121 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDecodeAllMultiDocument(t *testing.T) {
+	yamlContent := `
+kind: Deployment
+metadata:
+  name: job
+---
+kind: SecretValue
+metadata:
+  name: password
+---
+`
+
+	objs, err := DecodeAll([]byte(yamlContent), "multi.yaml")
+	if err != nil {
+		t.Fatalf("Failed to decode manifests: %v", err)
+	}
+	if len(objs) != 2 {
+		t.Fatalf("Expected 2 objects, got %d", len(objs))
+	}
+	if objs[0].Kind != KindDeployment || objs[1].Kind != KindSecretValue {
+		t.Errorf("Expected Deployment then SecretValue, got %s then %s", objs[0].Kind, objs[1].Kind)
+	}
+	if objs[1].Source != "multi.yaml (document 2)" {
+		t.Errorf("Expected source 'multi.yaml (document 2)', got '%s'", objs[1].Source)
+	}
+}
+
+func TestDecodeAllJSONList(t *testing.T) {
+	jsonContent := `[
+  {"kind": "Namespace", "metadata": {"name": "team-a"}},
+  {"kind": "Namespace", "metadata": {"name": "team-b"}}
+]`
+
+	objs, err := DecodeAll([]byte(jsonContent), "list.json")
+	if err != nil {
+		t.Fatalf("Failed to decode manifests: %v", err)
+	}
+	if len(objs) != 2 {
+		t.Fatalf("Expected 2 objects, got %d", len(objs))
+	}
+	if objs[1].Name != "team-b" {
+		t.Errorf("Expected second namespace 'team-b', got '%s'", objs[1].Name)
+	}
+}
+
+func TestSortDependencyOrder(t *testing.T) {
+	objs := []*Object{
+		{Kind: KindDeployment, Name: "job-1"},
+		{Kind: KindSessionCluster, Name: "sc"},
+		{Kind: "Unknown", Name: "x"},
+		{Kind: KindSecretValue, Name: "sv"},
+		{Kind: KindDeployment, Name: "job-2"},
+		{Kind: KindDeploymentTarget, Name: "dt"},
+		{Kind: KindNamespace, Name: "ns"},
+	}
+
+	Sort(objs)
+
+	var got []string
+	for _, obj := range objs {
+		got = append(got, obj.String())
+	}
+	want := "Namespace/ns DeploymentTarget/dt SecretValue/sv SessionCluster/sc Deployment/job-1 Deployment/job-2 Unknown/x"
+	if strings.Join(got, " ") != want {
+		t.Errorf("Expected order %q, got %q", want, strings.Join(got, " "))
+	}
+}
+
+func TestReadDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "deployment.yaml"), "kind: Deployment\nmetadata:\n  name: job\n")
+	writeFile(t, filepath.Join(dir, "README.md"), "not a manifest")
+	writeFile(t, filepath.Join(dir, "nested", "namespace.json"), `{"kind": "Namespace", "metadata": {"name": "ns"}}`)
+
+	objs, err := Read(dir, false)
+	if err != nil {
+		t.Fatalf("Failed to read directory: %v", err)
+	}
+	if len(objs) != 1 {
+		t.Fatalf("Expected 1 object without recursion, got %d", len(objs))
+	}
+
+	objs, err = Read(dir, true)
+	if err != nil {
+		t.Fatalf("Failed to read directory recursively: %v", err)
+	}
+	if len(objs) != 2 {
+		t.Fatalf("Expected 2 objects with recursion, got %d", len(objs))
+	}
+	if objs[0].Kind != KindNamespace {
+		t.Errorf("Expected Namespace first, got %s", objs[0].Kind)
+	}
+}
+
+func TestReadEmptyFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "empty.yaml")
+	writeFile(t, file, "# nothing here\n")
+
+	if _, err := Read(file, false); err == nil {
+		t.Error("Expected error for manifest without objects")
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Column is a custom-columns column: a header and the JSONPath expression
+// that fills it
+type Column struct {
+	Header string
+	path   *JSONPathTemplate
+}
+
+// ParseColumns parses a custom-columns spec such as
+// "NAME:.metadata.name,STATE:.status.state"
+func ParseColumns(spec string) ([]Column, error) {
+	var columns []Column
+	for _, part := range strings.Split(spec, ",") {
+		header, expr, ok := strings.Cut(part, ":")
+		if !ok || header == "" || expr == "" {
+			return nil, fmt.Errorf("invalid custom-columns %q: expected HEADER:.json.path[,HEADER:.json.path...]", spec)
+		}
+		path, err := ParseJSONPath(RelaxedJSONPath(expr))
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, Column{Header: header, path: path})
+	}
+	return columns, nil
+}
+
+// printColumns prints one row per item, aligned like the resource tables.
+// Cells with several results are comma-separated; missing ones show <none>.
+func printColumns(w io.Writer, columns []Column, items []interface{}, noHeaders bool) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	if !noHeaders {
+		headers := make([]string, len(columns))
+		for i, column := range columns {
+			headers[i] = column.Header
+		}
+		fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	}
+	for _, item := range items {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			results := column.path.Results(item)
+			values := make([]string, 0, len(results))
+			for _, result := range results {
+				values = append(values, formatValue(result))
+			}
+			cells[i] = strings.Join(values, ",")
+			if len(results) == 0 {
+				cells[i] = "<none>"
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,414 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONPathTemplate is a parsed kubectl-style JSONPath template such as
+// "{range .items[*]}{.metadata.name}{'\t'}{.status.state}{'\n'}{end}".
+//
+// Supported: literal text, {.field.sub}, {['field']}, {[n]} and {[-n]},
+// {[*]} and {.*}, filters like {[?(@.status.state=="RUNNING")]} with ==, !=
+// or bare existence, string literals such as {"\n"}, and range/end blocks.
+// A missing field yields no result rather than an error, so templates work
+// on objects that omit empty fields.
+type JSONPathTemplate struct {
+	nodes []jsonPathNode
+}
+
+type jsonPathNode struct {
+	text    string     // literal output
+	path    []pathStep // expression to evaluate or range over
+	body    []jsonPathNode
+	isPath  bool
+	isRange bool
+}
+
+type stepKind int
+
+const (
+	stepField stepKind = iota
+	stepIndex
+	stepWildcard
+	stepFilter
+)
+
+type pathStep struct {
+	kind  stepKind
+	field string
+	index int
+
+	// filter: keep elements whose filterPath result compares to value
+	filterPath []pathStep
+	op         string // "==", "!=" or "" for existence
+	value      string
+}
+
+// RelaxedJSONPath accepts the shorthand forms kubectl accepts, so that
+// ".metadata.name" and "metadata.name" both mean "{.metadata.name}"
+func RelaxedJSONPath(expr string) string {
+	if strings.Contains(expr, "{") {
+		return expr
+	}
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(expr, ".") && !strings.HasPrefix(expr, "$") && !strings.HasPrefix(expr, "[") {
+		expr = "." + expr
+	}
+	return "{" + expr + "}"
+}
+
+// ParseJSONPath parses a JSONPath template
+func ParseJSONPath(text string) (*JSONPathTemplate, error) {
+	nodes, _, ended, err := parseNodes(text)
+	if err == nil && ended {
+		err = fmt.Errorf("{end} without range")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid jsonpath %q: %w", text, err)
+	}
+	return &JSONPathTemplate{nodes: nodes}, nil
+}
+
+// parseNodes parses text up to the end of input or an {end} action. It
+// returns the text after {end} and whether {end} was found.
+func parseNodes(text string) ([]jsonPathNode, string, bool, error) {
+	var nodes []jsonPathNode
+	for text != "" {
+		open := strings.IndexByte(text, '{')
+		if open < 0 {
+			nodes = append(nodes, jsonPathNode{text: text})
+			break
+		}
+		if open > 0 {
+			nodes = append(nodes, jsonPathNode{text: text[:open]})
+		}
+
+		end, err := closingBrace(text, open)
+		if err != nil {
+			return nil, "", false, err
+		}
+		action := strings.TrimSpace(text[open+1 : end])
+		text = text[end+1:]
+
+		switch {
+		case action == "end":
+			return nodes, text, true, nil
+		case strings.HasPrefix(action, "range "):
+			path, err := parsePath(strings.TrimSpace(strings.TrimPrefix(action, "range ")))
+			if err != nil {
+				return nil, "", false, err
+			}
+			body, rest, ended, err := parseNodes(text)
+			if err != nil {
+				return nil, "", false, err
+			}
+			if !ended {
+				return nil, "", false, fmt.Errorf("range without {end}")
+			}
+			nodes = append(nodes, jsonPathNode{path: path, body: body, isRange: true})
+			text = rest
+		case strings.HasPrefix(action, `"`) || strings.HasPrefix(action, "'"):
+			literal, err := unquote(action)
+			if err != nil {
+				return nil, "", false, err
+			}
+			nodes = append(nodes, jsonPathNode{text: literal})
+		default:
+			path, err := parsePath(action)
+			if err != nil {
+				return nil, "", false, err
+			}
+			nodes = append(nodes, jsonPathNode{path: path, isPath: true})
+		}
+	}
+	return nodes, "", false, nil
+}
+
+// closingBrace finds the '}' closing the '{' at open, skipping quoted text
+func closingBrace(text string, open int) (int, error) {
+	var quote byte
+	for i := open + 1; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '}':
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unclosed '{'")
+}
+
+func unquote(s string) (string, error) {
+	if strings.HasPrefix(s, "'") {
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return "", fmt.Errorf("unterminated string %s", s)
+		}
+		// Single-quoted literals support the same escapes as double-quoted ones
+		s = `"` + strings.ReplaceAll(s[1:len(s)-1], `"`, `\"`) + `"`
+	}
+	literal, err := strconv.Unquote(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid string %s", s)
+	}
+	return literal, nil
+}
+
+// parsePath parses an expression such as .items[*].metadata.name. A leading
+// $ or @ refers to the current object, as does an empty path or ".".
+func parsePath(expr string) ([]pathStep, error) {
+	s := strings.TrimPrefix(strings.TrimPrefix(expr, "$"), "@")
+	var steps []pathStep
+	for s != "" {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			if strings.HasPrefix(s, ".") {
+				return nil, fmt.Errorf("recursive descent (..) is not supported")
+			}
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			field := s[:end]
+			s = s[end:]
+			switch field {
+			case "":
+				// "." alone or before "[" refers to the current object
+			case "*":
+				steps = append(steps, pathStep{kind: stepWildcard})
+			default:
+				steps = append(steps, pathStep{kind: stepField, field: field})
+			}
+		case '[':
+			end, err := closingBracket(s)
+			if err != nil {
+				return nil, err
+			}
+			step, err := parseBracket(strings.TrimSpace(s[1:end]))
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+			s = s[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected %q in %q", s[0], expr)
+		}
+	}
+	return steps, nil
+}
+
+// closingBracket finds the ']' closing the '[' at the start of s
+func closingBracket(s string) (int, error) {
+	var quote byte
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unclosed '[' in %q", s)
+}
+
+func parseBracket(content string) (pathStep, error) {
+	switch {
+	case content == "*":
+		return pathStep{kind: stepWildcard}, nil
+	case strings.HasPrefix(content, "'") || strings.HasPrefix(content, `"`):
+		field, err := unquote(content)
+		if err != nil {
+			return pathStep{}, err
+		}
+		return pathStep{kind: stepField, field: field}, nil
+	case strings.HasPrefix(content, "?(") && strings.HasSuffix(content, ")"):
+		return parseFilter(strings.TrimSpace(content[2 : len(content)-1]))
+	}
+	index, err := strconv.Atoi(content)
+	if err != nil {
+		return pathStep{}, fmt.Errorf("unsupported subscript [%s]", content)
+	}
+	return pathStep{kind: stepIndex, index: index}, nil
+}
+
+// parseFilter parses @.path == value, @.path != value or @.path
+func parseFilter(expr string) (pathStep, error) {
+	step := pathStep{kind: stepFilter}
+	left := expr
+	for _, op := range []string{"==", "!="} {
+		if l, r, ok := strings.Cut(expr, op); ok {
+			left = strings.TrimSpace(l)
+			value := strings.TrimSpace(r)
+			if strings.HasPrefix(value, "'") || strings.HasPrefix(value, `"`) {
+				unquoted, err := unquote(value)
+				if err != nil {
+					return pathStep{}, err
+				}
+				value = unquoted
+			}
+			step.op = op
+			step.value = value
+			break
+		}
+	}
+	if !strings.HasPrefix(left, "@") {
+		return pathStep{}, fmt.Errorf("filter %q must start with @", expr)
+	}
+	path, err := parsePath(left)
+	if err != nil {
+		return pathStep{}, err
+	}
+	step.filterPath = path
+	return step, nil
+}
+
+// Execute renders the template for data, which must be the generic form of
+// a JSON document. Multiple results of one expression are separated by
+// spaces.
+func (t *JSONPathTemplate) Execute(w io.Writer, data interface{}) error {
+	return executeNodes(w, t.nodes, data)
+}
+
+func executeNodes(w io.Writer, nodes []jsonPathNode, data interface{}) error {
+	for _, node := range nodes {
+		switch {
+		case node.isRange:
+			for _, item := range evalPath(node.path, []interface{}{data}) {
+				if err := executeNodes(w, node.body, item); err != nil {
+					return err
+				}
+			}
+		case node.isPath:
+			results := evalPath(node.path, []interface{}{data})
+			values := make([]string, len(results))
+			for i, result := range results {
+				values[i] = formatValue(result)
+			}
+			if _, err := io.WriteString(w, strings.Join(values, " ")); err != nil {
+				return err
+			}
+		default:
+			if _, err := io.WriteString(w, node.text); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Results evaluates a path expression (without braces) against data and
+// returns every match
+func (t *JSONPathTemplate) Results(data interface{}) []interface{} {
+	var results []interface{}
+	for _, node := range t.nodes {
+		if node.isPath {
+			results = append(results, evalPath(node.path, []interface{}{data})...)
+		}
+	}
+	return results
+}
+
+func evalPath(steps []pathStep, nodes []interface{}) []interface{} {
+	for _, step := range steps {
+		var next []interface{}
+		for _, node := range nodes {
+			next = append(next, evalStep(step, node)...)
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+func evalStep(step pathStep, node interface{}) []interface{} {
+	switch step.kind {
+	case stepField:
+		if m, ok := node.(map[string]interface{}); ok {
+			if v, ok := m[step.field]; ok {
+				return []interface{}{v}
+			}
+		}
+	case stepIndex:
+		if list, ok := node.([]interface{}); ok {
+			i := step.index
+			if i < 0 {
+				i += len(list)
+			}
+			if i >= 0 && i < len(list) {
+				return []interface{}{list[i]}
+			}
+		}
+	case stepWildcard:
+		switch t := node.(type) {
+		case []interface{}:
+			return t
+		case map[string]interface{}:
+			keys := make([]string, 0, len(t))
+			for k := range t {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			values := make([]interface{}, len(keys))
+			for i, k := range keys {
+				values[i] = t[k]
+			}
+			return values
+		}
+	case stepFilter:
+		list, ok := node.([]interface{})
+		if !ok {
+			return nil
+		}
+		var matches []interface{}
+		for _, item := range list {
+			if matchFilter(step, item) {
+				matches = append(matches, item)
+			}
+		}
+		return matches
+	}
+	return nil
+}
+
+func matchFilter(step pathStep, item interface{}) bool {
+	results := evalPath(step.filterPath, []interface{}{item})
+	switch step.op {
+	case "":
+		return len(results) > 0
+	case "==":
+		for _, r := range results {
+			if formatValue(r) == step.value {
+				return true
+			}
+		}
+		return false
+	default:
+		for _, r := range results {
+			if formatValue(r) == step.value {
+				return false
+			}
+		}
+		return true
+	}
+}
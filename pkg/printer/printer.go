@@ -0,0 +1,223 @@
+// Package printer renders API objects in the structured output formats
+// selected with -o: json, yaml, name, jsonpath, go-template and
+// custom-columns. Table output is specific to each resource and stays with
+// the commands.
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output format names
+const (
+	Table         = "table"
+	JSON          = "json"
+	YAML          = "yaml"
+	Name          = "name"
+	JSONPath      = "jsonpath"
+	GoTemplate    = "go-template"
+	CustomColumns = "custom-columns"
+)
+
+// Formats lists the accepted -o values for help and error messages
+var Formats = []string{Table, JSON, YAML, Name, JSONPath + "=...", GoTemplate + "=...", CustomColumns + "=..."}
+
+// Format is a parsed -o value. Arg holds the template or column spec of
+// jsonpath, go-template and custom-columns.
+type Format struct {
+	Name string
+	Arg  string
+}
+
+// Options tune how objects are printed
+type Options struct {
+	// Kind prefixes names in the name format, as in deployment/my-job
+	Kind string
+	// NoHeaders omits the header row of custom-columns
+	NoHeaders bool
+}
+
+// ParseFormat parses an -o value. An empty value selects the table format.
+func ParseFormat(value string) (Format, error) {
+	name, arg, hasArg := strings.Cut(value, "=")
+	switch name {
+	case "":
+		if !hasArg {
+			return Format{Name: Table}, nil
+		}
+	case Table, JSON, YAML, Name:
+		if !hasArg {
+			return Format{Name: name}, nil
+		}
+		return Format{}, fmt.Errorf("output format %s does not take an argument", name)
+	case JSONPath, GoTemplate, CustomColumns:
+		if arg == "" {
+			return Format{}, fmt.Errorf("output format %s requires an argument, as in -o %s=...", name, name)
+		}
+		return Format{Name: name, Arg: arg}, nil
+	}
+	return Format{}, fmt.Errorf("unknown output format %q (expected one of %s)", value, strings.Join(Formats, ", "))
+}
+
+// IsTable reports whether the format is the resource-specific table
+func (f Format) IsTable() bool {
+	return f.Name == Table
+}
+
+// Print writes obj to w in format f. Slices are printed as lists: json and
+// yaml print them as arrays, jsonpath and go-template see them under .items
+// (as kubectl does) and custom-columns and name print one line per element.
+func Print(w io.Writer, f Format, obj interface{}, opts Options) error {
+	// Print empty lists as [] rather than null
+	if v := reflect.ValueOf(obj); v.Kind() == reflect.Slice && v.IsNil() {
+		obj = reflect.MakeSlice(v.Type(), 0, 0).Interface()
+	}
+
+	switch f.Name {
+	case JSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(obj)
+	case YAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(obj); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+
+	data, err := toGeneric(obj)
+	if err != nil {
+		return err
+	}
+	items, isList := data.([]interface{})
+	if !isList {
+		items = []interface{}{data}
+	}
+
+	switch f.Name {
+	case Name:
+		return printNames(w, items, opts.Kind)
+	case JSONPath:
+		tmpl, err := ParseJSONPath(RelaxedJSONPath(f.Arg))
+		if err != nil {
+			return err
+		}
+		if err := tmpl.Execute(w, listRoot(data, isList)); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w)
+		return err
+	case GoTemplate:
+		tmpl, err := template.New("output").Parse(f.Arg)
+		if err != nil {
+			return fmt.Errorf("invalid go-template: %w", err)
+		}
+		if err := tmpl.Execute(w, listRoot(data, isList)); err != nil {
+			return fmt.Errorf("failed to execute go-template: %w", err)
+		}
+		return nil
+	case CustomColumns:
+		columns, err := ParseColumns(f.Arg)
+		if err != nil {
+			return err
+		}
+		return printColumns(w, columns, items, opts.NoHeaders)
+	}
+	return fmt.Errorf("output format %s cannot be printed generically", f.Name)
+}
+
+// listRoot exposes lists under .items so templates can range over them
+func listRoot(data interface{}, isList bool) interface{} {
+	if isList {
+		return map[string]interface{}{"items": data}
+	}
+	return data
+}
+
+// printNames prints kind/name for every object. The name is taken from
+// metadata.name, falling back to name, filename and metadata.id for
+// resources that are not named through metadata. Resource names such as
+// namespaces/default/apiTokens/ci are shortened to their last segment.
+func printNames(w io.Writer, items []interface{}, kind string) error {
+	for _, item := range items {
+		name := ""
+		for _, path := range [][]string{{"metadata", "name"}, {"name"}, {"filename"}, {"metadata", "id"}} {
+			if v, ok := lookup(item, path...).(string); ok && v != "" {
+				name = v[strings.LastIndex(v, "/")+1:]
+				break
+			}
+		}
+		if name == "" {
+			return fmt.Errorf("object has no name")
+		}
+		if kind != "" {
+			name = strings.ToLower(kind) + "/" + name
+		}
+		if _, err := fmt.Fprintln(w, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func lookup(v interface{}, path ...string) interface{} {
+	for _, key := range path {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return v
+}
+
+// toGeneric converts obj to the maps, slices and scalars of its JSON
+// encoding, so that templates address fields by their JSON names. Numbers
+// are kept as json.Number to print integers without an exponent.
+func toGeneric(obj interface{}) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// formatValue prints a single template result: scalars as text, objects and
+// arrays as compact JSON
+func formatValue(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case json.Number:
+		return t.String()
+	case bool:
+		if t {
+			return "true"
+		}
+		return "false"
+	default:
+		data, err := json.Marshal(t)
+		if err != nil {
+			return fmt.Sprint(t)
+		}
+		return string(data)
+	}
+}
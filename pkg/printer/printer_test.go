@@ -0,0 +1,193 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type testMetadata struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type testResource struct {
+	Metadata testMetadata `json:"metadata"`
+	Spec     struct {
+		Parallelism int `json:"parallelism"`
+	} `json:"spec"`
+	Status struct {
+		State string `json:"state,omitempty"`
+	} `json:"status"`
+}
+
+func testResources() []testResource {
+	var a, b testResource
+	a.Metadata = testMetadata{Name: "orders", Labels: map[string]string{"team": "data"}}
+	a.Spec.Parallelism = 4
+	a.Status.State = "RUNNING"
+	b.Metadata = testMetadata{Name: "payments"}
+	b.Spec.Parallelism = 1000000
+	return []testResource{a, b}
+}
+
+func render(t *testing.T, format string, obj interface{}, opts Options) string {
+	t.Helper()
+	f, err := ParseFormat(format)
+	if err != nil {
+		t.Fatalf("Failed to parse format %q: %v", format, err)
+	}
+	var buf bytes.Buffer
+	if err := Print(&buf, f, obj, opts); err != nil {
+		t.Fatalf("Failed to print %q: %v", format, err)
+	}
+	return buf.String()
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Format
+		wantErr bool
+	}{
+		{value: "", want: Format{Name: Table}},
+		{value: "table", want: Format{Name: Table}},
+		{value: "yaml", want: Format{Name: YAML}},
+		{value: "name", want: Format{Name: Name}},
+		{value: "jsonpath={.metadata.name}", want: Format{Name: JSONPath, Arg: "{.metadata.name}"}},
+		{value: "custom-columns=A:.a=b", want: Format{Name: CustomColumns, Arg: "A:.a=b"}},
+		{value: "jsonpath", wantErr: true},
+		{value: "jsonpath=", wantErr: true},
+		{value: "json=x", wantErr: true},
+		{value: "xml", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseFormat(%q): expected error, got %+v", tt.value, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseFormat(%q) = %+v, %v; want %+v", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestPrintJSONAndYAML(t *testing.T) {
+	resources := testResources()
+
+	got := render(t, "json", resources[0], Options{})
+	if !strings.HasPrefix(got, "{\n  \"metadata\": {\n    \"name\": \"orders\"") {
+		t.Errorf("Unexpected JSON output:\n%s", got)
+	}
+
+	got = render(t, "yaml", resources[:1], Options{})
+	if !strings.HasPrefix(got, "- metadata:\n    name: orders\n") {
+		t.Errorf("Unexpected YAML output:\n%s", got)
+	}
+}
+
+func TestPrintName(t *testing.T) {
+	got := render(t, "name", testResources(), Options{Kind: "Deployment"})
+	if got != "deployment/orders\ndeployment/payments\n" {
+		t.Errorf("Unexpected name output %q", got)
+	}
+
+	// Resources named outside metadata, such as artifacts
+	got = render(t, "name", []map[string]string{{"filename": "job.jar"}}, Options{})
+	if got != "job.jar\n" {
+		t.Errorf("Unexpected name output %q", got)
+	}
+
+	got = render(t, "name", map[string]string{"name": "namespaces/default/apiTokens/ci"}, Options{Kind: "APIToken"})
+	if got != "apitoken/ci\n" {
+		t.Errorf("Unexpected name output %q", got)
+	}
+}
+
+func TestPrintJSONPath(t *testing.T) {
+	resources := testResources()
+	tests := []struct {
+		template string
+		obj      interface{}
+		want     string
+	}{
+		{"{.metadata.name}", resources[0], "orders\n"},
+		{".metadata.name", resources[0], "orders\n"},
+		{"spec.parallelism", resources[1], "1000000\n"},
+		{"{.items[*].metadata.name}", resources, "orders payments\n"},
+		{"{.items[-1].metadata.name}", resources, "payments\n"},
+		{"{range .items[*]}{.metadata.name}{'\\t'}{.spec.parallelism}{'\\n'}{end}", resources, "orders\t4\npayments\t1000000\n\n"},
+		{`{.items[?(@.status.state=="RUNNING")].metadata.name}`, resources, "orders\n"},
+		{`{.items[?(@.metadata.labels.team)].metadata.name}`, resources, "orders\n"},
+		{`{.metadata.labels['team']}`, resources[0], "data\n"},
+		{"{.metadata.labels}", resources[0], "{\"team\":\"data\"}\n"},
+		{"{.status.missing}", resources[0], "\n"},
+		{"name: {.metadata.name}", resources[0], "name: orders\n"},
+	}
+	for _, tt := range tests {
+		if got := render(t, "jsonpath="+tt.template, tt.obj, Options{}); got != tt.want {
+			t.Errorf("jsonpath=%s: got %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestParseJSONPathErrors(t *testing.T) {
+	for _, template := range []string{
+		"{.metadata.name",
+		"{range .items[*]}{.metadata.name}",
+		"{end}",
+		"{..name}",
+		"{.items[abc]}",
+	} {
+		if _, err := ParseJSONPath(template); err == nil {
+			t.Errorf("ParseJSONPath(%q): expected error", template)
+		}
+	}
+}
+
+func TestPrintGoTemplate(t *testing.T) {
+	got := render(t, "go-template={{range .items}}{{.metadata.name}}={{.spec.parallelism}} {{end}}", testResources(), Options{})
+	if got != "orders=4 payments=1000000 " {
+		t.Errorf("Unexpected go-template output %q", got)
+	}
+
+	f := Format{Name: GoTemplate, Arg: "{{.metadata.name"}
+	if err := Print(&bytes.Buffer{}, f, testResources()[0], Options{}); err == nil {
+		t.Error("Expected error for invalid go-template")
+	}
+}
+
+func TestPrintCustomColumns(t *testing.T) {
+	got := render(t, "custom-columns=NAME:.metadata.name,STATE:.status.state,PARALLELISM:spec.parallelism", testResources(), Options{})
+	want := "NAME       STATE     PARALLELISM\n" +
+		"orders     RUNNING   4\n" +
+		"payments   <none>    1000000\n"
+	if got != want {
+		t.Errorf("Unexpected custom-columns output:\n%s\nwant:\n%s", got, want)
+	}
+
+	got = render(t, "custom-columns=NAME:.metadata.name", testResources()[0], Options{NoHeaders: true})
+	if got != "orders\n" {
+		t.Errorf("Unexpected custom-columns output without headers %q", got)
+	}
+
+	if _, err := ParseColumns("NAME"); err == nil {
+		t.Error("Expected error for a column without expression")
+	}
+}
+
+func TestPrintEmptyList(t *testing.T) {
+	var none []testResource
+	if got := render(t, "json", none, Options{}); got != "[]\n" {
+		t.Errorf("Unexpected JSON for nil list %q", got)
+	}
+	if got := render(t, "jsonpath={.items[*].metadata.name}", none, Options{}); got != "\n" {
+		t.Errorf("Unexpected jsonpath output for nil list %q", got)
+	}
+	if got := render(t, "custom-columns=NAME:.metadata.name", none, Options{}); got != "NAME\n" {
+		t.Errorf("Unexpected custom-columns output for nil list %q", got)
+	}
+}